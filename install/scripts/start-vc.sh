@@ -20,6 +20,8 @@
 # This script launches ETH2 validator clients for Stader docker stack; only edit if you know what you're doing ;)
 
 GWW_GRAFFITI_FILE="/addons/gww/graffiti.txt"
+KEYMANAGER_TOKEN_FILE="/validators/keymanager-token.txt"
+TEMPLATE_GRAFFITI_FILE="/validators/graffiti.txt"
 
 # Set up the network-based flags
 if [ "$NETWORK" = "mainnet" ]; then
@@ -53,6 +55,16 @@ if [ ! -f "/validators/$FEE_RECIPIENT_FILE" ]; then
     exit 1
 fi
 
+# Create the keymanager API token shared with the Stader node
+if [ "$KEYMANAGER_API_ENABLED" = "true" ] && [ ! -f "$KEYMANAGER_TOKEN_FILE" ]; then
+    tr -dc 'a-f0-9' < /dev/urandom | head -c 64 > $KEYMANAGER_TOKEN_FILE
+fi
+
+# Without the keymanager API, use the graffiti template the Stader node rendered for every validator
+if [ "$KEYMANAGER_API_ENABLED" != "true" ] && [ -f "$TEMPLATE_GRAFFITI_FILE" ]; then
+    GRAFFITI=$(cat $TEMPLATE_GRAFFITI_FILE)
+fi
+
 
 # Lighthouse startup
 if [ "$CC_CLIENT" = "lighthouse" ]; then
@@ -86,6 +98,20 @@ if [ "$CC_CLIENT" = "lighthouse" ]; then
         CMD="$CMD --builder-proposals"
     fi
 
+    # Lighthouse can't fetch keys from Web3Signer, so the Stader node keeps remote key definitions for it
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        mkdir -p /validators/web3signer/lighthouse
+        CMD="$CMD --validators-dir /validators/web3signer/lighthouse"
+    fi
+
+    if [ "$KEYMANAGER_API_ENABLED" = "true" ]; then
+        CMD="$CMD --http --http-address 0.0.0.0 --http-port $VC_KEYMANAGER_PORT --unencrypted-http-transport --http-token-path $KEYMANAGER_TOKEN_FILE"
+    fi
+
+    if [ ! -z "$VC_GAS_LIMIT" ] && [ "$VC_GAS_LIMIT" != "0" ]; then
+        CMD="$CMD --gas-limit $VC_GAS_LIMIT"
+    fi
+
     if [ "$ENABLE_METRICS" = "true" ]; then
         CMD="$CMD --metrics --metrics-address 0.0.0.0 --metrics-port $VC_METRICS_PORT"
     fi
@@ -134,6 +160,18 @@ if [ "$CC_CLIENT" = "lodestar" ]; then
         CMD="$CMD --builder"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --externalSigner.url $WEB3SIGNER_URL --externalSigner.fetch"
+    fi
+
+    if [ "$KEYMANAGER_API_ENABLED" = "true" ]; then
+        CMD="$CMD --keymanager --keymanager.address 0.0.0.0 --keymanager.port $VC_KEYMANAGER_PORT --keymanager.tokenFile $KEYMANAGER_TOKEN_FILE"
+    fi
+
+    if [ ! -z "$VC_GAS_LIMIT" ] && [ "$VC_GAS_LIMIT" != "0" ]; then
+        CMD="$CMD --defaultGasLimit $VC_GAS_LIMIT"
+    fi
+
     if [ "$ENABLE_METRICS" = "true" ]; then
         CMD="$CMD --metrics --metrics.address 0.0.0.0 --metrics.port $VC_METRICS_PORT"
     fi
@@ -169,6 +207,18 @@ if [ "$CC_CLIENT" = "nimbus" ]; then
         CMD="$CMD --payload-builder"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --web3-signer-url=$WEB3SIGNER_URL"
+    fi
+
+    if [ "$KEYMANAGER_API_ENABLED" = "true" ]; then
+        CMD="$CMD --keymanager --keymanager-address=0.0.0.0 --keymanager-port=$VC_KEYMANAGER_PORT --keymanager-token-file=$KEYMANAGER_TOKEN_FILE"
+    fi
+
+    if [ ! -z "$VC_GAS_LIMIT" ] && [ "$VC_GAS_LIMIT" != "0" ]; then
+        CMD="$CMD --suggested-gas-limit=$VC_GAS_LIMIT"
+    fi
+
     if [ "$ENABLE_METRICS" = "true" ]; then
         CMD="$CMD --metrics --metrics-address=0.0.0.0 --metrics-port=$VC_METRICS_PORT"
     fi
@@ -210,6 +260,18 @@ if [ "$CC_CLIENT" = "prysm" ]; then
         CMD="$CMD --enable-builder"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --validators-external-signer-url=$WEB3SIGNER_URL --validators-external-signer-public-keys=$WEB3SIGNER_URL/api/v1/eth2/publicKeys"
+    fi
+
+    if [ "$KEYMANAGER_API_ENABLED" = "true" ]; then
+        CMD="$CMD --rpc --grpc-gateway-host=0.0.0.0 --grpc-gateway-port=$VC_KEYMANAGER_PORT --keymanager-token-file=$KEYMANAGER_TOKEN_FILE"
+    fi
+
+    if [ ! -z "$VC_GAS_LIMIT" ] && [ "$VC_GAS_LIMIT" != "0" ]; then
+        CMD="$CMD --suggested-gas-limit=$VC_GAS_LIMIT"
+    fi
+
     if [ "$DOPPELGANGER_DETECTION" = "true" ]; then
         CMD="$CMD --enable-doppelganger"
     fi
@@ -257,6 +319,18 @@ if [ "$CC_CLIENT" = "teku" ]; then
         CMD="$CMD --validators-builder-registration-default-enabled=true"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --validators-external-signer-url=$WEB3SIGNER_URL --validators-external-signer-public-keys=external-signer"
+    fi
+
+    if [ "$KEYMANAGER_API_ENABLED" = "true" ]; then
+        CMD="$CMD --validator-api-enabled=true --validator-api-interface=0.0.0.0 --validator-api-port=$VC_KEYMANAGER_PORT --validator-api-host-allowlist=* --validator-api-bearer-file=$KEYMANAGER_TOKEN_FILE --Xvalidator-api-ssl-enabled=false --Xvalidator-api-unsafe-hosts-enabled=true"
+    fi
+
+    if [ ! -z "$VC_GAS_LIMIT" ] && [ "$VC_GAS_LIMIT" != "0" ]; then
+        CMD="$CMD --validators-builder-registration-default-gas-limit=$VC_GAS_LIMIT"
+    fi
+
     if [ "$ENABLE_METRICS" = "true" ]; then
         CMD="$CMD --metrics-enabled=true --metrics-interface=0.0.0.0 --metrics-port=$VC_METRICS_PORT --metrics-host-allowlist=*"
     fi
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package services
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/stader-labs/stader-node/shared/services/beacon"
@@ -35,14 +37,33 @@ import (
 
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
 type BeaconClientManager struct {
-	primaryBc       beacon.Client
-	fallbackBc      beacon.Client
+	endpoints       []*bcEndpoint
+	preferred       int
+	policy          *ClientPolicy
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
+	statusLock      sync.Mutex
 }
 
+// A single Beacon client endpoint and its health
+type bcEndpoint struct {
+	name   string
+	client beacon.Client
+	guard  *endpointGuard
+	ready  bool
+	status api.ClientStatus
+
+	// Cached after the first successful request, used to convert slots to times
+	eth2Config *beacon.Eth2Config
+}
+
+// Event stream reconnection settings
+const (
+	eventStreamMinRetryDelay time.Duration = 5 * time.Second
+	eventStreamMaxRetryDelay time.Duration = 2 * time.Minute
+	eventStreamStableTime    time.Duration = 5 * time.Minute
+)
+
 // This is a signature for a wrapped Beacon client function that only returns an error
 type bcFunction0 func(beacon.Client) error
 
@@ -75,35 +96,51 @@ func NewBeaconClientManager(cfg *config.StaderConfig) (*BeaconClientManager, err
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
-	var fallbackProvider string
+	// Fallback CCs, in order of priority
+	providers := []string{primaryProvider}
 	if cfg.UseFallbackClients.Value == true {
+		var fallbackProvider string
+		var additionalProviders string
 		if cfg.IsNativeMode {
 			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+			additionalProviders = cfg.FallbackNormal.AdditionalCcHttpUrls.Value.(string)
 		} else {
 			switch selectedCC {
 			case cfgtypes.ConsensusClient_Prysm:
 				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+				additionalProviders = cfg.FallbackPrysm.AdditionalCcHttpUrls.Value.(string)
 			default:
 				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+				additionalProviders = cfg.FallbackNormal.AdditionalCcHttpUrls.Value.(string)
 			}
 		}
+		if fallbackProvider != "" {
+			providers = append(providers, fallbackProvider)
+		}
+		providers = append(providers, splitUrlList(additionalProviders)...)
 	}
 
-	var primaryBc beacon.Client
-	var fallbackBc beacon.Client
+	policy, err := NewClientPolicy(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
-	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+	logger := log.NewColorLogger(color.FgHiBlue)
+	endpoints := make([]*bcEndpoint, len(providers))
+	for i, provider := range providers {
+		name := getEndpointName(i)
+		endpoints[i] = &bcEndpoint{
+			name:   name,
+			client: client.NewStandardHttpClient(provider, logger),
+			guard:  newEndpointGuard(name, policy),
+			ready:  true,
+		}
 	}
 
 	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
+		endpoints: endpoints,
+		policy:    policy,
+		logger:    logger,
 	}, nil
 
 }
@@ -254,15 +291,26 @@ func (m *BeaconClientManager) GetValidatorSyncDuties(indices []uint64, epoch uin
 	return result.(map[uint64]bool), nil
 }
 
-// Get a validator's proposer duties
-func (m *BeaconClientManager) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+// Get the slots each validator is scheduled to propose in an epoch
+func (m *BeaconClientManager) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64][]uint64, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorProposerDuties(indices, epoch)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.(map[uint64]uint64), nil
+	return result.(map[uint64][]uint64), nil
+}
+
+// Get whether validators were seen participating in an epoch
+func (m *BeaconClientManager) GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorLiveness(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]bool), nil
 }
 
 // Get the Beacon chain's domain data
@@ -314,55 +362,187 @@ func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Com
 	return result.([]beacon.Committee), nil
 }
 
+// Stream events from the Beacon node, reconnecting whenever the stream drops.
+// Each reconnection uses the preferred client, moving on to the next one if the last stream on a client failed.
+// Blocks until the context is cancelled.
+func (m *BeaconClientManager) SubscribeToEvents(ctx context.Context, topics []beacon.EventTopic, handler func(beacon.Event)) error {
+
+	retryDelay := eventStreamMinRetryDelay
+	var lastFailed *bcEndpoint
+	for {
+		// Pick a client to stream from; the stream runs alongside CheckStatus, so the ready flags are read under its lock
+		m.statusLock.Lock()
+		order := m.getEndpointOrder()
+		m.statusLock.Unlock()
+		var endpoint *bcEndpoint
+		for _, candidate := range order {
+			if candidate != lastFailed {
+				endpoint = candidate
+				break
+			}
+		}
+		if endpoint == nil && len(order) > 0 {
+			endpoint = order[0]
+		}
+
+		if endpoint == nil {
+			m.logger.Printlnf("WARNING: No Beacon clients are ready for the event stream, retrying in %s...", retryDelay)
+			lastFailed = nil
+		} else {
+			start := time.Now()
+			err := endpoint.client.SubscribeToEvents(ctx, topics, handler)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if time.Since(start) > eventStreamStableTime {
+				retryDelay = eventStreamMinRetryDelay
+			}
+			m.logger.Printlnf("WARNING: %s Beacon client event stream dropped (%s), reconnecting in %s...", endpoint.name, err.Error(), retryDelay)
+			if m.isDisconnected(err) {
+				m.statusLock.Lock()
+				endpoint.ready = false
+				m.statusLock.Unlock()
+			}
+			lastFailed = endpoint
+		}
+
+		// Wait before reconnecting
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay):
+		}
+		m.statusLock.Lock()
+		noneReady := len(m.getEndpointOrder()) == 0
+		m.statusLock.Unlock()
+		if noneReady {
+			m.CheckStatus()
+		}
+		retryDelay *= 2
+		if retryDelay > eventStreamMaxRetryDelay {
+			retryDelay = eventStreamMaxRetryDelay
+		}
+	}
+
+}
+
+// Get the last known head of each client, in order of priority
+func (m *BeaconClientManager) GetEndpointHeads() []ClientEndpointHead {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	heads := make([]ClientEndpointHead, len(m.endpoints))
+	for i, endpoint := range m.endpoints {
+		heads[i] = ClientEndpointHead{
+			Endpoint:        endpoint.guard.getStats().Endpoint,
+			HeadNumber:      endpoint.status.HeadNumber,
+			HeadTime:        endpoint.status.HeadTime,
+			FinalizedEpoch:  endpoint.status.FinalizedEpoch,
+			IsStalled:       endpoint.status.IsStalled,
+			FinalityStalled: endpoint.status.FinalityStalled,
+		}
+	}
+	return heads
+}
+
+// Get the request counters of each client, in order of priority
+func (m *BeaconClientManager) GetEndpointStats() []ClientEndpointStats {
+	stats := make([]ClientEndpointStats, len(m.endpoints))
+	for i, endpoint := range m.endpoints {
+		stats[i] = endpoint.guard.getStats()
+	}
+	return stats
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
 
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
-	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
-	}
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
 
 	// Ignore the sync check and just use the predefined settings if requested
-	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
-		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
+	if !m.ignoreSyncCheck {
+		// Get the status of each client
+		headSlots := make([]uint64, len(m.endpoints))
+		bestHeadSlot := uint64(0)
+		bestFinalizedEpoch := uint64(0)
+		for i, endpoint := range m.endpoints {
+			endpoint.status, headSlots[i] = m.checkBcStatus(endpoint)
+			if endpoint.status.IsStalled && endpoint.ready {
+				m.logger.Printlnf("WARNING: %s Beacon client is stuck at slot %d", endpoint.name, endpoint.status.HeadNumber)
+			}
+			if endpoint.status.IsSynced && headSlots[i] > bestHeadSlot {
+				bestHeadSlot = headSlots[i]
+			}
+			if endpoint.status.IsWorking && endpoint.status.FinalizedEpoch > bestFinalizedEpoch {
+				bestFinalizedEpoch = endpoint.status.FinalizedEpoch
+			}
 		}
-		return status
-	}
 
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
+		// A client that stopped finalizing is only unhealthy if another one is ahead of it; otherwise the whole network has stopped finalizing
+		for _, endpoint := range m.endpoints {
+			if !endpoint.status.FinalityStalled {
+				continue
+			}
+			if endpoint.status.FinalizedEpoch < bestFinalizedEpoch {
+				if endpoint.ready {
+					m.logger.Printlnf("WARNING: %s Beacon client is stuck at finalized epoch %d while another client has finalized epoch %d", endpoint.name, endpoint.status.FinalizedEpoch, bestFinalizedEpoch)
+				}
+				endpoint.status.Error = fmt.Sprintf("Client has not finalized an epoch since epoch %d, but another client has finalized epoch %d", endpoint.status.FinalizedEpoch, bestFinalizedEpoch)
+				endpoint.status.IsSynced = false
+			} else if endpoint.ready {
+				m.logger.Printlnf("WARNING: the network has not finalized an epoch since epoch %d", endpoint.status.FinalizedEpoch)
+			}
+		}
 
-	// Get the fallback BC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+		// Score them and flag the ready ones
+		scores := make([]float64, len(m.endpoints))
+		for i, endpoint := range m.endpoints {
+			headLag := uint64(0)
+			if headSlots[i] < bestHeadSlot {
+				headLag = bestHeadSlot - headSlots[i]
+			}
+			scores[i] = getHealthScore(endpoint.status, headLag, endpoint.guard.takeErrorRate())
+			endpoint.status.HealthScore = scores[i]
+			endpoint.ready = (endpoint.status.IsWorking && endpoint.status.IsSynced)
+		}
+		m.preferred = getPreferredEndpoint(scores)
+	} else {
+		for _, endpoint := range m.endpoints {
+			endpoint.status.IsWorking = endpoint.ready
+			endpoint.status.IsSynced = endpoint.ready
+		}
 	}
 
-	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
+	status := &api.ClientManagerStatus{
+		PrimaryClientStatus:        m.endpoints[0].status,
+		FallbackEnabled:            len(m.endpoints) > 1,
+		AdditionalFallbackStatuses: []api.ClientStatus{},
+	}
+	if status.FallbackEnabled {
+		status.FallbackClientStatus = m.endpoints[1].status
+		for _, endpoint := range m.endpoints[2:] {
+			status.AdditionalFallbackStatuses = append(status.AdditionalFallbackStatuses, endpoint.status)
+		}
+	}
 	return status
 
 }
 
-// Check the client status
-func checkBcStatus(client beacon.Client) api.ClientStatus {
+// Check the client status, and get its head slot
+func (m *BeaconClientManager) checkBcStatus(endpoint *bcEndpoint) (api.ClientStatus, uint64) {
 
 	status := api.ClientStatus{}
 
 	// Get the fallback's sync progress
-	syncStatus, err := client.GetSyncStatus()
+	syncStatus, err := endpoint.client.GetSyncStatus()
 	if err != nil {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
 		status.IsWorking = false
-		return status
+		return status, 0
 	}
 
 	// Return the sync status
@@ -374,138 +554,162 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsWorking = true
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
+		return status, syncStatus.HeadSlot
 	}
-	return status
-
-}
-
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
+	status.HeadNumber = syncStatus.HeadSlot
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
+	// Get the chain config to convert slots into times
+	if endpoint.eth2Config == nil {
+		eth2Config, err := endpoint.client.GetEth2Config()
 		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
+			return status, syncStatus.HeadSlot
 		}
-		// If there's no error, return the result
-		return nil
+		endpoint.eth2Config = &eth2Config
 	}
+	eth2Config := endpoint.eth2Config
+	status.HeadTime = time.Unix(int64(eth2Config.GenesisTime+syncStatus.HeadSlot*eth2Config.SecondsPerSlot), 0)
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
-			}
+	// Make sure the head is still moving
+	if time.Since(status.HeadTime) > m.policy.HeadStallThreshold {
+		status.Error = fmt.Sprintf("Client claims to have finished syncing, but its head slot (%d) was from %s ago. It is likely stuck or doesn't have enough peers", status.HeadNumber, time.Since(status.HeadTime).Round(time.Second))
+		status.IsSynced = false
+		status.IsStalled = true
+		status.SyncProgress = 0
+		return status, syncStatus.HeadSlot
+	}
 
-			// If it's a different error, just return it
-			return err
+	// Make sure it's still finalizing
+	head, err := endpoint.client.GetBeaconHead()
+	if err != nil {
+		return status, syncStatus.HeadSlot
+	}
+	status.FinalizedEpoch = head.FinalizedEpoch
+	if eth2Config.SecondsPerEpoch > 0 && m.policy.FinalityStallEpochs > 0 {
+		currentTime := uint64(time.Now().Unix())
+		if currentTime > eth2Config.GenesisTime {
+			currentEpoch := (currentTime - eth2Config.GenesisTime) / eth2Config.SecondsPerEpoch
+			status.FinalityStalled = currentEpoch > head.FinalizedEpoch+m.policy.FinalityStallEpochs
 		}
-		// If there's no error, return the result
-		return nil
 	}
+	return status, syncStatus.HeadSlot
 
-	return fmt.Errorf("no Beacon clients were ready")
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
+// Get the ready clients in the order requests should try them: the preferred one first, then the rest in order of priority.
+// The caller must hold statusLock, since CheckStatus updates the ready flags.
+func (m *BeaconClientManager) getEndpointOrder() []*bcEndpoint {
+	order := []*bcEndpoint{}
+	if m.preferred >= 0 && m.preferred < len(m.endpoints) && m.endpoints[m.preferred].ready {
+		order = append(order, m.endpoints[m.preferred])
 	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, err
+	for i, endpoint := range m.endpoints {
+		if i != m.preferred && endpoint.ready {
+			order = append(order, endpoint)
 		}
-		// If there's no error, return the result
-		return result, nil
 	}
+	return order
+}
 
-	return nil, fmt.Errorf("no Beacon clients were ready")
+// Check if the primary client is ready
+func (m *BeaconClientManager) isPrimaryReady() bool {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	return m.endpoints[0].ready
+}
 
+// Check if any fallback client is ready
+func (m *BeaconClientManager) isFallbackReady() bool {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	for _, endpoint := range m.endpoints[1:] {
+		if endpoint.ready {
+			return true
+		}
+	}
+	return false
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
+// Transient errors are retried on the same client first, and clients that keep failing are skipped until their circuit breaker closes.
+func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
+	m.statusLock.Lock()
+	order := m.getEndpointOrder()
+	m.statusLock.Unlock()
+	if len(order) == 0 {
+		return fmt.Errorf("no Beacon clients were ready")
+	}
+
+	// Skip clients while their circuit breaker is open, unless none of the others can be used either
+	available := []*bcEndpoint{}
+	for _, endpoint := range order {
+		if endpoint.guard.isAvailable() {
+			available = append(available, endpoint)
 		}
-		// If there's no error, return the result
-		return result1, result2, nil
+	}
+	if len(available) == 0 {
+		available = order[:1]
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
+	for i, endpoint := range available {
+		shouldFailover, err := endpoint.guard.run(func() error {
+			return function(endpoint.client)
+		})
+		if !shouldFailover {
+			// If it succeeded or the client returned a normal error, return the result
+			return err
+		}
+
+		// Log it and try the next client
+		isLast := (i == len(available)-1)
+		nextMessage := ", using the next client..."
+		if isLast {
+			nextMessage = ""
+		}
+		if m.isDisconnected(err) {
+			m.logger.Printlnf("WARNING: %s Beacon client disconnected (%s)%s", endpoint.name, err.Error(), nextMessage)
+			m.statusLock.Lock()
+			endpoint.ready = false
+			m.statusLock.Unlock()
+		} else {
+			m.logger.Printlnf("WARNING: %s Beacon client failed (%s)%s", endpoint.name, err.Error(), nextMessage)
+		}
+		if isLast {
+			if len(m.endpoints) == 1 {
+				return err
 			}
-			// If it's a different error, just return it
-			return nil, nil, err
+			return fmt.Errorf("all Beacon clients failed: %w", err)
 		}
-		// If there's no error, return the result
-		return result1, result2, nil
+		endpoint.guard.recordFailover()
 	}
 
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
+	return fmt.Errorf("no Beacon clients were ready")
+}
 
+// Attempts to run a function progressively through each client until one succeeds or they all fail.
+func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
+	var result interface{}
+	err := m.runFunction0(func(client beacon.Client) error {
+		var err error
+		result, err = function(client)
+		return err
+	})
+	return result, err
+}
+
+// Attempts to run a function progressively through each client until one succeeds or they all fail.
+func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
+	var result1 interface{}
+	var result2 interface{}
+	err := m.runFunction0(func(client beacon.Client) error {
+		var err error
+		result1, result2, err = function(client)
+		return err
+	})
+	return result1, result2, err
 }
 
 // Returns true if the error was a connection failure and a backup client is available
 func (m *BeaconClientManager) isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return isDisconnectError(err)
 }
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// A Beacon client that reports a fixed sync status and head, and fails every other call
+type syncStatusClient struct {
+	beacon.Client
+	syncStatus beacon.SyncStatus
+	err        error
+	head       beacon.BeaconHead
+}
+
+func (c *syncStatusClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	return c.syncStatus, c.err
+}
+
+func (c *syncStatusClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return beacon.Eth2Config{SecondsPerSlot: 12, SecondsPerEpoch: 384}, nil
+}
+
+func (c *syncStatusClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	return c.head, nil
+}
+
+func TestCheckBcStatus(t *testing.T) {
+	manager := &BeaconClientManager{
+		policy: &ClientPolicy{HeadStallThreshold: time.Minute, FinalityStallEpochs: 4},
+		logger: log.NewColorLogger(color.FgYellow),
+	}
+	// With a genesis time of 0, the slot and epoch are the current ones
+	slotAt := func(age time.Duration) uint64 {
+		return uint64(time.Now().Add(-age).Unix()) / 12
+	}
+	currentEpoch := uint64(time.Now().Unix()) / 384
+
+	tests := []struct {
+		name                    string
+		client                  *syncStatusClient
+		expectedWorking         bool
+		expectedSynced          bool
+		expectedStalled         bool
+		expectedFinalityStalled bool
+	}{
+		{"synced", &syncStatusClient{syncStatus: beacon.SyncStatus{HeadSlot: slotAt(0)}, head: beacon.BeaconHead{FinalizedEpoch: currentEpoch - 2}}, true, true, false, false},
+		{"stalled head", &syncStatusClient{syncStatus: beacon.SyncStatus{HeadSlot: slotAt(5 * time.Minute)}}, true, false, true, false},
+		{"finality stalled", &syncStatusClient{syncStatus: beacon.SyncStatus{HeadSlot: slotAt(0)}, head: beacon.BeaconHead{FinalizedEpoch: currentEpoch - 10}}, true, true, false, true},
+		{"still syncing", &syncStatusClient{syncStatus: beacon.SyncStatus{Syncing: true, Progress: 0.5}}, true, false, false, false},
+		{"sync check failed", &syncStatusClient{err: errors.New("unavailable")}, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, _ := manager.checkBcStatus(&bcEndpoint{name: "Primary", client: test.client})
+			if status.IsWorking != test.expectedWorking || status.IsSynced != test.expectedSynced || status.IsStalled != test.expectedStalled {
+				t.Fatalf("got working %t, synced %t and stalled %t, expected %t, %t and %t", status.IsWorking, status.IsSynced, status.IsStalled, test.expectedWorking, test.expectedSynced, test.expectedStalled)
+			}
+			if status.FinalityStalled != test.expectedFinalityStalled {
+				t.Fatalf("got finality stalled %t, expected %t", status.FinalityStalled, test.expectedFinalityStalled)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+)
+
+// Wakes up a daemon loop when the Beacon node reports a matching event, so the loop can react to new epochs or finality instead of only sleeping.
+// If the event stream is unavailable, Wait falls back to the loop's regular interval.
+type BeaconEventTrigger struct {
+	signal chan struct{}
+}
+
+// Start streaming events in the background until the context is cancelled; a nil filter accepts every event on the topics
+func NewBeaconEventTrigger(ctx context.Context, bc *BeaconClientManager, topics []beacon.EventTopic, filter func(beacon.Event) bool) *BeaconEventTrigger {
+	trigger := &BeaconEventTrigger{
+		signal: make(chan struct{}, 1),
+	}
+	go func() {
+		_ = bc.SubscribeToEvents(ctx, topics, func(event beacon.Event) {
+			if filter != nil && !filter(event) {
+				return
+			}
+			// Events that arrive while the loop is busy are coalesced into one wakeup
+			select {
+			case trigger.signal <- struct{}{}:
+			default:
+			}
+		})
+	}()
+	return trigger
+}
+
+// Trigger on every new epoch
+func NewEpochTrigger(ctx context.Context, bc *BeaconClientManager) *BeaconEventTrigger {
+	return NewBeaconEventTrigger(ctx, bc, []beacon.EventTopic{beacon.EventTopic_Head}, func(event beacon.Event) bool {
+		return event.Head != nil && event.Head.EpochTransition
+	})
+}
+
+// Trigger on every newly finalized checkpoint
+func NewFinalityTrigger(ctx context.Context, bc *BeaconClientManager) *BeaconEventTrigger {
+	return NewBeaconEventTrigger(ctx, bc, []beacon.EventTopic{beacon.EventTopic_FinalizedCheckpoint}, nil)
+}
+
+// Wait for the next matching event, or until the timeout passes
+func (t *BeaconEventTrigger) Wait(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-t.signal:
+	case <-timer.C:
+	}
+}
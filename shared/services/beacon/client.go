@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package beacon
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stader-labs/stader-node/shared/types/config"
@@ -36,6 +38,7 @@ type ValidatorStatusOptions struct {
 type SyncStatus struct {
 	Syncing  bool
 	Progress float64
+	HeadSlot uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
@@ -101,6 +104,45 @@ type NodeVersion struct {
 	Version string
 }
 
+// Beacon node event stream topics
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_ChainReorg          EventTopic = "chain_reorg"
+	EventTopic_VoluntaryExit       EventTopic = "voluntary_exit"
+)
+
+// An event from the Beacon node event stream; only the field matching the topic is set
+type Event struct {
+	Topic               EventTopic
+	Head                *HeadEvent
+	FinalizedCheckpoint *FinalizedCheckpointEvent
+	ChainReorg          *ChainReorgEvent
+	VoluntaryExit       *VoluntaryExitEvent
+}
+type HeadEvent struct {
+	Slot            uint64
+	Block           common.Hash
+	EpochTransition bool
+}
+type FinalizedCheckpointEvent struct {
+	Epoch uint64
+	Block common.Hash
+}
+type ChainReorgEvent struct {
+	Slot         uint64
+	Epoch        uint64
+	Depth        uint64
+	OldHeadBlock common.Hash
+	NewHeadBlock common.Hash
+}
+type VoluntaryExitEvent struct {
+	Epoch          uint64
+	ValidatorIndex uint64
+}
+
 // Beacon client type
 type BeaconClientType int
 
@@ -148,10 +190,12 @@ type Client interface {
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error)
 	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
-	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
+	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64][]uint64, error)
+	GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetExitDomainData(domainType []byte, network config.Network) ([]byte, error)
 	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) ([]Committee, error)
+	SubscribeToEvents(ctx context.Context, topics []EventTopic, handler func(Event)) error
 }
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+)
+
+const (
+	RequestEventsPath = "/eth/v1/events?topics=%s"
+
+	// SSE data lines can hold large payloads, so don't rely on the scanner's 64kB default
+	maxEventLineSize = 1024 * 1024
+)
+
+// How long the stream can go without a head event before it's assumed dead; head events arrive every slot even when the chain isn't finalizing
+var headEventIdleTimeout = 2 * time.Minute
+
+// Subscribe to the Beacon node event stream and pass each event to the handler.
+// The stream always includes head events so a dead connection can be detected; they're only passed on if they were requested.
+// Blocks until the context is cancelled or the stream is closed by the Beacon node.
+func (c *StandardHttpClient) SubscribeToEvents(ctx context.Context, topics []beacon.EventTopic, handler func(beacon.Event)) error {
+
+	if len(topics) == 0 {
+		return fmt.Errorf("no event topics were provided")
+	}
+	topicStrings := []string{}
+	forwardHeads := false
+	for _, topic := range topics {
+		topicStrings = append(topicStrings, string(topic))
+		if topic == beacon.EventTopic_Head {
+			forwardHeads = true
+		}
+	}
+	if !forwardHeads {
+		topicStrings = append(topicStrings, string(beacon.EventTopic_Head))
+	}
+
+	// Cancel the stream if the Beacon node goes without a new head for longer than a healthy node would
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watchdog := time.AfterFunc(headEventIdleTimeout, cancel)
+	defer watchdog.Stop()
+
+	// Open the stream
+	request, err := http.NewRequestWithContext(streamCtx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, fmt.Sprintf(RequestEventsPath, strings.Join(topicStrings, ","))), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		if streamCtx.Err() != nil && ctx.Err() == nil {
+			return fmt.Errorf("Beacon node didn't answer the event subscription within %s", headEventIdleTimeout)
+		}
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		return newHTTPStatusError("Could not subscribe to events", response.StatusCode, body)
+	}
+
+	// Read events; each one is a block of "event:" and "data:" lines terminated by a blank line
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLineSize)
+	var eventName string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if eventName != "" && data.Len() > 0 {
+				topic := beacon.EventTopic(eventName)
+				if topic == beacon.EventTopic_Head {
+					watchdog.Reset(headEventIdleTimeout)
+				}
+
+				// One bad event shouldn't cost the rest of the stream
+				event, err := parseEvent(topic, []byte(data.String()))
+				if err != nil {
+					c.logger.Printlnf("WARNING: Skipping %s event from the Beacon node: %s", eventName, err.Error())
+				} else if event != nil && (topic != beacon.EventTopic_Head || forwardHeads) {
+					handler(*event)
+				}
+			}
+			eventName = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if streamCtx.Err() != nil {
+		return fmt.Errorf("Event stream had no new head for %s", headEventIdleTimeout)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Error reading event stream: %w", err)
+	}
+	return fmt.Errorf("Event stream was closed by the Beacon node")
+
+}
+
+// Decode an event payload; returns nil for topics we don't handle
+func parseEvent(topic beacon.EventTopic, data []byte) (*beacon.Event, error) {
+
+	event := beacon.Event{Topic: topic}
+	switch topic {
+	case beacon.EventTopic_Head:
+		var head HeadEventData
+		if err := json.Unmarshal(data, &head); err != nil {
+			return nil, fmt.Errorf("Could not decode head event: %w", err)
+		}
+		event.Head = &beacon.HeadEvent{
+			Slot:            uint64(head.Slot),
+			Block:           common.HexToHash(head.Block),
+			EpochTransition: head.EpochTransition,
+		}
+	case beacon.EventTopic_FinalizedCheckpoint:
+		var checkpoint FinalizedCheckpointEventData
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return nil, fmt.Errorf("Could not decode finalized checkpoint event: %w", err)
+		}
+		event.FinalizedCheckpoint = &beacon.FinalizedCheckpointEvent{
+			Epoch: uint64(checkpoint.Epoch),
+			Block: common.HexToHash(checkpoint.Block),
+		}
+	case beacon.EventTopic_ChainReorg:
+		var reorg ChainReorgEventData
+		if err := json.Unmarshal(data, &reorg); err != nil {
+			return nil, fmt.Errorf("Could not decode chain reorg event: %w", err)
+		}
+		event.ChainReorg = &beacon.ChainReorgEvent{
+			Slot:         uint64(reorg.Slot),
+			Epoch:        uint64(reorg.Epoch),
+			Depth:        uint64(reorg.Depth),
+			OldHeadBlock: common.HexToHash(reorg.OldHeadBlock),
+			NewHeadBlock: common.HexToHash(reorg.NewHeadBlock),
+		}
+	case beacon.EventTopic_VoluntaryExit:
+		var exit VoluntaryExitEventData
+		if err := json.Unmarshal(data, &exit); err != nil {
+			return nil, fmt.Errorf("Could not decode voluntary exit event: %w", err)
+		}
+		event.VoluntaryExit = &beacon.VoluntaryExitEvent{
+			Epoch:          uint64(exit.Message.Epoch),
+			ValidatorIndex: uint64(exit.Message.ValidatorIndex),
+		}
+	default:
+		return nil, nil
+	}
+	return &event, nil
+
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+const (
+	testHeadEvent       = "event: head\ndata: {\"slot\":\"10\",\"block\":\"0x01\",\"epoch_transition\":false}\n\n"
+	testCheckpointEvent = "event: finalized_checkpoint\ndata: {\"block\":\"0x02\",\"epoch\":\"3\"}\n\n"
+)
+
+// An SSE server that writes the given chunks, waiting between each one, then closes the stream
+func newEventServer(t *testing.T, interval time.Duration, chunks ...string) (*httptest.Server, *string) {
+	var topics string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		topics = r.URL.Query().Get("topics")
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, chunk := range chunks {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(interval):
+			}
+			fmt.Fprint(w, chunk)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &topics
+}
+
+func newTestEventClient(url string) *StandardHttpClient {
+	return NewStandardHttpClient(url, log.NewColorLogger(color.FgHiBlue))
+}
+
+func TestSubscribeToEvents(t *testing.T) {
+
+	stream := []string{
+		": keep-alive\n\n",
+		testHeadEvent,
+		// Split across writes and lines
+		"event: finalized_checkpoint\ndata: {\"block\":\"0x02\",",
+		"\ndata: \"epoch\":\"3\"}\n\n",
+		"event: finalized_checkpoint\ndata: {not json}\n\n",
+		"event: payload_attributes\ndata: {}\n\n",
+		"event: chain_reorg\ndata: {\"slot\":\"11\",\"depth\":\"2\",\"old_head_block\":\"0x03\",\"new_head_block\":\"0x04\",\"epoch\":\"0\"}\n\n",
+	}
+
+	tests := []struct {
+		name           string
+		topics         []beacon.EventTopic
+		expectedTopics string
+		expectedEvents []beacon.EventTopic
+	}{
+		{
+			name:           "head events are added for the watchdog but not passed on",
+			topics:         []beacon.EventTopic{beacon.EventTopic_FinalizedCheckpoint, beacon.EventTopic_ChainReorg},
+			expectedTopics: "finalized_checkpoint,chain_reorg,head",
+			expectedEvents: []beacon.EventTopic{beacon.EventTopic_FinalizedCheckpoint, beacon.EventTopic_ChainReorg},
+		},
+		{
+			name:           "requested head events are passed on",
+			topics:         []beacon.EventTopic{beacon.EventTopic_Head, beacon.EventTopic_FinalizedCheckpoint, beacon.EventTopic_ChainReorg},
+			expectedTopics: "head,finalized_checkpoint,chain_reorg",
+			expectedEvents: []beacon.EventTopic{beacon.EventTopic_Head, beacon.EventTopic_FinalizedCheckpoint, beacon.EventTopic_ChainReorg},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, topics := newEventServer(t, time.Millisecond, stream...)
+			events := []beacon.Event{}
+			err := newTestEventClient(server.URL).SubscribeToEvents(context.Background(), test.topics, func(event beacon.Event) {
+				events = append(events, event)
+			})
+			if err == nil || !strings.Contains(err.Error(), "closed by the Beacon node") {
+				t.Fatalf("got %v, expected the stream to be closed", err)
+			}
+			if *topics != test.expectedTopics {
+				t.Fatalf("subscribed to %q, expected %q", *topics, test.expectedTopics)
+			}
+			if len(events) != len(test.expectedEvents) {
+				t.Fatalf("got %d events, expected %d", len(events), len(test.expectedEvents))
+			}
+			for i, event := range events {
+				if event.Topic != test.expectedEvents[i] {
+					t.Fatalf("event %d is %s, expected %s", i, event.Topic, test.expectedEvents[i])
+				}
+				switch event.Topic {
+				case beacon.EventTopic_Head:
+					if event.Head.Slot != 10 {
+						t.Fatalf("got head slot %d, expected 10", event.Head.Slot)
+					}
+				case beacon.EventTopic_FinalizedCheckpoint:
+					if event.FinalizedCheckpoint.Epoch != 3 {
+						t.Fatalf("got finalized epoch %d, expected 3", event.FinalizedCheckpoint.Epoch)
+					}
+				case beacon.EventTopic_ChainReorg:
+					if event.ChainReorg.Depth != 2 {
+						t.Fatalf("got reorg depth %d, expected 2", event.ChainReorg.Depth)
+					}
+				}
+			}
+		})
+	}
+
+}
+
+func TestSubscribeToEventsWatchdog(t *testing.T) {
+	defaultTimeout := headEventIdleTimeout
+	headEventIdleTimeout = 200 * time.Millisecond
+	t.Cleanup(func() {
+		headEventIdleTimeout = defaultTimeout
+	})
+
+	repeat := func(event string) []string {
+		chunks := []string{}
+		for i := 0; i < 20; i++ {
+			chunks = append(chunks, event)
+		}
+		return chunks
+	}
+
+	tests := []struct {
+		name          string
+		events        string
+		expectedError string
+	}{
+		// Finalized checkpoints can stop for hours during non-finality, so only new heads keep the stream alive
+		{"heads keep the stream alive", testHeadEvent, "closed by the Beacon node"},
+		{"other events don't", testCheckpointEvent, "no new head"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _ := newEventServer(t, 50*time.Millisecond, repeat(test.events)...)
+			err := newTestEventClient(server.URL).SubscribeToEvents(context.Background(), []beacon.EventTopic{beacon.EventTopic_FinalizedCheckpoint}, func(beacon.Event) {})
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("got %v, expected an error about %q", err, test.expectedError)
+			}
+		})
+	}
+}
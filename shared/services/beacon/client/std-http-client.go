@@ -28,6 +28,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -40,6 +41,7 @@ import (
 	"github.com/stader-labs/stader-node/shared/services/beacon"
 	"github.com/stader-labs/stader-node/shared/utils/eth2"
 	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/log"
 )
 
 // Config
@@ -61,6 +63,7 @@ const (
 	RequestBeaconBlockPath           = "/eth/v2/beacon/blocks/%s"
 	RequestValidatorSyncDuties       = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties   = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorLivenessPath     = "/eth/v1/validator/liveness/%s"
 
 	MaxRequestValidatorsCount     = 600
 	threadLimit               int = 6
@@ -69,12 +72,17 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	logger          log.ColorLogger
+
+	// Set once the node rejects POST validator queries, so the GET variant is used from then on
+	postValidatorsUnsupported int32
 }
 
 // Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+func NewStandardHttpClient(providerAddress string, logger log.ColorLogger) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		logger:          logger,
 	}
 }
 
@@ -104,6 +112,7 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 	return beacon.SyncStatus{
 		Syncing:  syncStatus.Data.IsSyncing,
 		Progress: progress,
+		HeadSlot: uint64(syncStatus.Data.HeadSlot),
 	}, nil
 
 }
@@ -344,7 +353,7 @@ func (c *StandardHttpClient) GetValidatorSyncDuties(indices []uint64, epoch uint
 		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("Could not get validator sync duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return nil, newHTTPStatusError("Could not get validator sync duties", status, responseBody)
 	}
 
 	var response SyncDutiesResponse
@@ -368,17 +377,17 @@ func (c *StandardHttpClient) GetValidatorSyncDuties(indices []uint64, epoch uint
 	return validatorMap, nil
 }
 
-// Sums proposer duties per validators for a given epoch
-func (c *StandardHttpClient) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+// Get the slots each validator is scheduled to propose in a given epoch
+func (c *StandardHttpClient) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64][]uint64, error) {
 
-	// Perform the post request
+	// Perform the request
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
 
 	if err != nil {
 		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("Could not get validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return nil, newHTTPStatusError("Could not get validator proposer duties", status, responseBody)
 	}
 
 	var response ProposerDutiesResponse
@@ -387,21 +396,61 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []uint64, epoch
 	}
 
 	// Map the results
-	proposerMap := make(map[uint64]uint64)
+	proposerMap := make(map[uint64][]uint64)
 
 	for _, index := range indices {
-		proposerMap[index] = 0
-		for _, duty := range response.Data {
-			if uint64(duty.ValidatorIndex) == index {
-				proposerMap[index]++
-				break
-			}
+		proposerMap[index] = []uint64{}
+	}
+	for _, duty := range response.Data {
+		slots, exists := proposerMap[uint64(duty.ValidatorIndex)]
+		if exists {
+			proposerMap[uint64(duty.ValidatorIndex)] = append(slots, uint64(duty.Slot))
 		}
 	}
 
 	return proposerMap, nil
 }
 
+// Get whether validators were seen participating (attesting or proposing) in a given epoch
+func (c *StandardHttpClient) GetValidatorLiveness(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+
+	// Convert incoming uint64 validator indices into an array of string for the request
+	indicesStrings := make([]string, len(indices))
+
+	for i, index := range indices {
+		indicesStrings[i] = strconv.FormatUint(index, 10)
+	}
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorLivenessPath, strconv.FormatUint(epoch, 10)), indicesStrings)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, newHTTPStatusError("Could not get validator liveness", status, responseBody)
+	}
+
+	var response LivenessResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator liveness data: %w", err)
+	}
+
+	// Map the results
+	livenessMap := make(map[uint64]bool)
+
+	for _, index := range indices {
+		livenessMap[index] = false
+	}
+	for _, liveness := range response.Data {
+		if _, exists := livenessMap[uint64(liveness.Index)]; exists {
+			livenessMap[uint64(liveness.Index)] = liveness.IsLive
+		}
+	}
+
+	return livenessMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
 
@@ -429,15 +478,7 @@ func (c *StandardHttpClient) GetExitDomainData(domainType []byte, network config
 	genesis, err := c.getGenesis()
 
 	// Get fork version
-	var capellaForkVersion string
-	// TODO - we currently only support mainnet and testnet envs. We will have to update this as we change n/ws
-	if network == config.Network_Mainnet {
-		capellaForkVersion = eth2.MainnetCapellaForkVersion
-	} else {
-		capellaForkVersion = eth2.GoerliCapellaForkVersion
-	}
-
-	decodedForkVersion, err := hexutil.Decode(capellaForkVersion)
+	decodedForkVersion, err := hexutil.Decode(eth2.GetExitForkVersion(network))
 	if err != nil {
 		return []byte{}, err
 	}
@@ -575,7 +616,7 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 		return SyncStatusResponse{}, fmt.Errorf("Could not get node sync status: %w", err)
 	}
 	if status != http.StatusOK {
-		return SyncStatusResponse{}, fmt.Errorf("Could not get node sync status: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return SyncStatusResponse{}, newHTTPStatusError("Could not get node sync status", status, responseBody)
 	}
 	var syncStatus SyncStatusResponse
 	if err := json.Unmarshal(responseBody, &syncStatus); err != nil {
@@ -590,7 +631,7 @@ func (c *StandardHttpClient) getNodeVersion() (NodeVersionResponse, error) {
 		return NodeVersionResponse{}, fmt.Errorf("Could not get node sync status: %w", err)
 	}
 	if status != http.StatusOK {
-		return NodeVersionResponse{}, fmt.Errorf("Could not get node sync status: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return NodeVersionResponse{}, newHTTPStatusError("Could not get node sync status", status, responseBody)
 	}
 	var nodeVersion NodeVersionResponse
 	if err := json.Unmarshal(responseBody, &nodeVersion); err != nil {
@@ -606,7 +647,7 @@ func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 		return Eth2ConfigResponse{}, fmt.Errorf("Could not get eth2 config: %w", err)
 	}
 	if status != http.StatusOK {
-		return Eth2ConfigResponse{}, fmt.Errorf("Could not get eth2 config: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return Eth2ConfigResponse{}, newHTTPStatusError("Could not get eth2 config", status, responseBody)
 	}
 	var eth2Config Eth2ConfigResponse
 	if err := json.Unmarshal(responseBody, &eth2Config); err != nil {
@@ -622,7 +663,7 @@ func (c *StandardHttpClient) getEth2DepositContract() (Eth2DepositContractRespon
 		return Eth2DepositContractResponse{}, fmt.Errorf("Could not get eth2 deposit contract: %w", err)
 	}
 	if status != http.StatusOK {
-		return Eth2DepositContractResponse{}, fmt.Errorf("Could not get eth2 deposit contract: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return Eth2DepositContractResponse{}, newHTTPStatusError("Could not get eth2 deposit contract", status, responseBody)
 	}
 	var eth2DepositContract Eth2DepositContractResponse
 	if err := json.Unmarshal(responseBody, &eth2DepositContract); err != nil {
@@ -638,7 +679,7 @@ func (c *StandardHttpClient) getGenesis() (GenesisResponse, error) {
 		return GenesisResponse{}, fmt.Errorf("Could not get genesis data: %w", err)
 	}
 	if status != http.StatusOK {
-		return GenesisResponse{}, fmt.Errorf("Could not get genesis data: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return GenesisResponse{}, newHTTPStatusError("Could not get genesis data", status, responseBody)
 	}
 	var genesis GenesisResponse
 	if err := json.Unmarshal(responseBody, &genesis); err != nil {
@@ -654,7 +695,7 @@ func (c *StandardHttpClient) getFinalityCheckpoints(stateId string) (FinalityChe
 		return FinalityCheckpointsResponse{}, fmt.Errorf("Could not get finality checkpoints: %w", err)
 	}
 	if status != http.StatusOK {
-		return FinalityCheckpointsResponse{}, fmt.Errorf("Could not get finality checkpoints: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return FinalityCheckpointsResponse{}, newHTTPStatusError("Could not get finality checkpoints", status, responseBody)
 	}
 	var finalityCheckpoints FinalityCheckpointsResponse
 	if err := json.Unmarshal(responseBody, &finalityCheckpoints); err != nil {
@@ -670,7 +711,7 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 		return ForkResponse{}, fmt.Errorf("Could not get fork data: %w", err)
 	}
 	if status != http.StatusOK {
-		return ForkResponse{}, fmt.Errorf("Could not get fork data: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return ForkResponse{}, newHTTPStatusError("Could not get fork data", status, responseBody)
 	}
 	var fork ForkResponse
 	if err := json.Unmarshal(responseBody, &fork); err != nil {
@@ -679,8 +720,22 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 	return fork, nil
 }
 
-// Get validators
+// Get validators, using the POST endpoint when the node supports it since the GET query string is limited in length
 func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
+	postNotFound := false
+	if len(pubkeys) > 0 && atomic.LoadInt32(&c.postValidatorsUnsupported) == 0 {
+		validators, status, err := c.postValidators(stateId, pubkeys)
+		switch status {
+		case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			atomic.StoreInt32(&c.postValidatorsUnsupported, 1)
+		case http.StatusNotFound:
+			// Either the node doesn't have the POST route or the state doesn't exist; the GET below tells them apart
+			postNotFound = true
+		default:
+			return validators, err
+		}
+	}
+
 	var query string
 	if len(pubkeys) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
@@ -690,7 +745,11 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
 	}
 	if status != http.StatusOK {
-		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return ValidatorsResponse{}, newHTTPStatusError("Could not get validators", status, responseBody)
+	}
+	if postNotFound {
+		// The state exists, so it was the POST route that was missing
+		atomic.StoreInt32(&c.postValidatorsUnsupported, 1)
 	}
 	var validators ValidatorsResponse
 	if err := json.Unmarshal(responseBody, &validators); err != nil {
@@ -699,7 +758,33 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 	return validators, nil
 }
 
-// Get validators by pubkeys and status options
+// Get validators via POST; returns the HTTP status, which is 404, 405 or 501 without an error if the node may not implement the endpoint
+func (c *StandardHttpClient) postValidators(stateId string, pubkeys []string) (ValidatorsResponse, int, error) {
+	request := ValidatorsRequest{
+		Ids:      pubkeys,
+		Statuses: []string{},
+	}
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorsPath, stateId), request)
+	if err != nil {
+		return ValidatorsResponse{}, status, fmt.Errorf("Could not get validators: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return ValidatorsResponse{}, status, nil
+	default:
+		return ValidatorsResponse{}, status, newHTTPStatusError("Could not get validators", status, responseBody)
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return ValidatorsResponse{}, status, fmt.Errorf("Could not decode validators: %w", err)
+	}
+	return validators, status, nil
+}
+
+// Get validators by pubkeys and status options.
+// Responses are always JSON: the Beacon API defines no SSZ encoding for the validators endpoint, and shared/types/eth2
+// has no BeaconState types to decode the SSZ debug state with, so SSZ decoding was left out.
 func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts *beacon.ValidatorStatusOptions) (ValidatorsResponse, error) {
 
 	// Get state ID
@@ -773,7 +858,7 @@ func (c *StandardHttpClient) postVoluntaryExit(request VoluntaryExitRequest) err
 		return fmt.Errorf("Could not broadcast exit for validator at index %d: %w", request.Message.ValidatorIndex, err)
 	}
 	if status != http.StatusOK {
-		return fmt.Errorf("Could not broadcast exit for validator at index %d: HTTP status %d; response body: '%s'", request.Message.ValidatorIndex, status, string(responseBody))
+		return newHTTPStatusError(fmt.Sprintf("Could not broadcast exit for validator at index %d", request.Message.ValidatorIndex), status, responseBody)
 	}
 	return nil
 }
@@ -788,7 +873,7 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 		return AttestationsResponse{}, false, nil
 	}
 	if status != http.StatusOK {
-		return AttestationsResponse{}, false, fmt.Errorf("Could not get attestations data for slot %s: HTTP status %d; response body: '%s'", blockId, status, string(responseBody))
+		return AttestationsResponse{}, false, newHTTPStatusError(fmt.Sprintf("Could not get attestations data for slot %s", blockId), status, responseBody)
 	}
 	var attestations AttestationsResponse
 	if err := json.Unmarshal(responseBody, &attestations); err != nil {
@@ -807,7 +892,7 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 		return BeaconBlockResponse{}, false, nil
 	}
 	if status != http.StatusOK {
-		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return BeaconBlockResponse{}, false, newHTTPStatusError("Could not get beacon block data", status, responseBody)
 	}
 	var beaconBlock BeaconBlockResponse
 	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
@@ -827,7 +912,7 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 		return CommitteesResponse{}, fmt.Errorf("Could not get committees: %w", err)
 	}
 	if status != http.StatusOK {
-		return CommitteesResponse{}, fmt.Errorf("Could not get committees: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return CommitteesResponse{}, newHTTPStatusError("Could not get committees", status, responseBody)
 	}
 	var committees CommitteesResponse
 	if err := json.Unmarshal(responseBody, &committees); err != nil {
@@ -888,3 +973,22 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// An error response from the Beacon node, so callers can tell failures apart by status code
+type HTTPStatusError struct {
+	Message    string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s: HTTP status %d; response body: '%s'", e.Message, e.StatusCode, e.Body)
+}
+
+func newHTTPStatusError(message string, statusCode int, body []byte) *HTTPStatusError {
+	return &HTTPStatusError{
+		Message:    message,
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+}
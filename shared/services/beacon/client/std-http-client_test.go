@@ -0,0 +1,91 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// A fake Beacon node that answers POST and GET validator queries with the given statuses
+type fakeValidatorsNode struct {
+	server     *httptest.Server
+	postStatus int
+	getStatus  int
+	posts      int32
+	gets       int32
+}
+
+func newFakeValidatorsNode(t *testing.T, postStatus int, getStatus int) *fakeValidatorsNode {
+	node := &fakeValidatorsNode{postStatus: postStatus, getStatus: getStatus}
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := node.getStatus
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&node.posts, 1)
+			status = node.postStatus
+		} else {
+			atomic.AddInt32(&node.gets, 1)
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"data":[{"index":"5","status":"active_ongoing"}]}`))
+		} else {
+			w.Write([]byte(`{"code":404,"message":"not found"}`))
+		}
+	}))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+func TestGetValidatorsPostFallback(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		postStatus    int
+		getStatus     int
+		expectedError bool
+
+		// Whether the second query still tries POST first
+		expectedSecondPost bool
+	}{
+		{"POST supported", http.StatusOK, http.StatusOK, false, true},
+		{"POST not allowed", http.StatusMethodNotAllowed, http.StatusOK, false, false},
+		{"POST not implemented", http.StatusNotImplemented, http.StatusOK, false, false},
+		{"POST route missing", http.StatusNotFound, http.StatusOK, false, false},
+		{"state not found", http.StatusNotFound, http.StatusNotFound, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := newFakeValidatorsNode(t, test.postStatus, test.getStatus)
+			client := NewStandardHttpClient(node.server.URL, log.NewColorLogger(color.FgHiBlue))
+
+			validators, err := client.getValidators("head", []string{"0x01"})
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(validators.Data) != 1 || validators.Data[0].Index != 5 {
+					t.Fatalf("got %+v, expected validator 5", validators.Data)
+				}
+			}
+			if test.postStatus == http.StatusOK && atomic.LoadInt32(&node.gets) != 0 {
+				t.Fatal("expected no GET query when POST works")
+			}
+
+			posts := atomic.LoadInt32(&node.posts)
+			client.getValidators("head", []string{"0x01"})
+			if secondPost := atomic.LoadInt32(&node.posts) > posts; secondPost != test.expectedSecondPost {
+				t.Fatalf("second query used POST: %t, expected %t", secondPost, test.expectedSecondPost)
+			}
+		})
+	}
+
+}
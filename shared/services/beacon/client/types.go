@@ -38,6 +38,11 @@ type VoluntaryExitMessage struct {
 	ValidatorIndex uinteger `json:"validator_index"`
 }
 
+type ValidatorsRequest struct {
+	Ids      []string `json:"ids"`
+	Statuses []string `json:"statuses"`
+}
+
 // Response types
 type SyncStatusResponse struct {
 	Data struct {
@@ -147,6 +152,14 @@ type ProposerDutiesResponse struct {
 }
 type ProposerDuty struct {
 	ValidatorIndex uinteger `json:"validator_index"`
+	Slot           uinteger `json:"slot"`
+}
+type LivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+type ValidatorLiveness struct {
+	Index  uinteger `json:"index"`
+	IsLive bool     `json:"is_live"`
 }
 
 type CommitteesResponse struct {
@@ -159,6 +172,28 @@ type Committee struct {
 	Validators []uinteger `json:"validators"`
 }
 
+// Event stream payloads
+type HeadEventData struct {
+	Slot            uinteger `json:"slot"`
+	Block           string   `json:"block"`
+	EpochTransition bool     `json:"epoch_transition"`
+}
+type FinalizedCheckpointEventData struct {
+	Block string   `json:"block"`
+	Epoch uinteger `json:"epoch"`
+}
+type ChainReorgEventData struct {
+	Slot         uinteger `json:"slot"`
+	Depth        uinteger `json:"depth"`
+	OldHeadBlock string   `json:"old_head_block"`
+	NewHeadBlock string   `json:"new_head_block"`
+	Epoch        uinteger `json:"epoch"`
+}
+type VoluntaryExitEventData struct {
+	Message   VoluntaryExitMessage `json:"message"`
+	Signature string               `json:"signature"`
+}
+
 type Attestation struct {
 	AggregationBits string `json:"aggregation_bits"`
 	Data            struct {
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stader-labs/stader-node/shared/services/beacon/client"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+// The JSON-RPC error code for a rate limited request (EIP-1474)
+const rpcLimitExceededCode int = -32005
+
+// The longest delay between two retries of the same request
+const maxRetryBackoff time.Duration = 10 * time.Second
+
+// Health scoring settings
+const (
+	maxHealthScore      float64 = 100
+	headLagPenalty      float64 = 10 // Per block or slot behind the best endpoint
+	maxHeadLagPenalty   float64 = 50
+	maxErrorRatePenalty float64 = 50
+
+	// A lower priority endpoint is only preferred over a higher priority one if it scores this much better
+	healthScoreTolerance float64 = 10
+)
+
+// Retry, rate limit and circuit breaker settings shared by the Execution and Beacon client managers
+type ClientPolicy struct {
+	MaxRetries              uint64
+	RetryBackoff            time.Duration
+	RateLimit               uint64
+	CircuitBreakerThreshold uint64
+	CircuitBreakerCooldown  time.Duration
+	HeadStallThreshold      time.Duration
+	FinalityStallEpochs     uint64
+}
+
+// Request counters for a single client endpoint
+type ClientEndpointStats struct {
+	Endpoint     string
+	Requests     uint64
+	Retries      uint64
+	Failures     uint64
+	Throttled    uint64
+	CircuitOpens uint64
+	Failovers    uint64
+}
+
+// Tracks the rate limit, circuit breaker state and counters of a single client endpoint
+type endpointGuard struct {
+	name                string
+	policy              *ClientPolicy
+	lock                sync.Mutex
+	scoredRequests      uint64
+	scoredFailures      uint64
+	tokens              float64
+	lastRefill          time.Time
+	consecutiveFailures uint64
+	openUntil           time.Time
+	stats               ClientEndpointStats
+}
+
+// Creates a client policy from the Stader config
+func NewClientPolicy(cfg *config.StaderConfig) (*ClientPolicy, error) {
+	retryBackoff, err := time.ParseDuration(cfg.ClientRetryBackoff.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid client retry backoff [%s]: %w", cfg.ClientRetryBackoff.Value, err)
+	}
+	cooldown, err := time.ParseDuration(cfg.ReconnectDelay.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid reconnect delay [%s]: %w", cfg.ReconnectDelay.Value, err)
+	}
+	headStallThreshold, err := time.ParseDuration(cfg.ClientHeadStallThreshold.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid client head stall threshold [%s]: %w", cfg.ClientHeadStallThreshold.Value, err)
+	}
+	return &ClientPolicy{
+		MaxRetries:              cfg.ClientMaxRetries.Value.(uint64),
+		RetryBackoff:            retryBackoff,
+		RateLimit:               cfg.ClientRateLimit.Value.(uint64),
+		CircuitBreakerThreshold: cfg.ClientCircuitBreakerThreshold.Value.(uint64),
+		CircuitBreakerCooldown:  cooldown,
+		HeadStallThreshold:      headStallThreshold,
+		FinalityStallEpochs:     cfg.ClientFinalityStallEpochs.Value.(uint64),
+	}, nil
+}
+
+func newEndpointGuard(name string, policy *ClientPolicy) *endpointGuard {
+	return &endpointGuard{
+		name:       name,
+		policy:     policy,
+		tokens:     float64(policy.RateLimit),
+		lastRefill: time.Now(),
+	}
+}
+
+// Run a request against the endpoint, retrying transient errors with exponential backoff.
+// Returns whether the final error means the endpoint is unhealthy and the request should fail over.
+func (g *endpointGuard) run(request func() error) (bool, error) {
+	backoff := g.policy.RetryBackoff
+	for attempt := uint64(0); ; attempt++ {
+		g.waitForRateLimit()
+		err := request()
+		g.lock.Lock()
+		g.stats.Requests++
+		g.lock.Unlock()
+
+		if err == nil {
+			g.recordSuccess()
+			return false, nil
+		}
+		if isDisconnectError(err) {
+			g.recordFailure()
+			return true, err
+		}
+		if !isRetryableError(err) {
+			// The endpoint answered, so it's healthy even though the request failed
+			g.recordSuccess()
+			return false, err
+		}
+		if attempt >= g.policy.MaxRetries {
+			g.recordFailure()
+			return true, err
+		}
+
+		g.lock.Lock()
+		g.stats.Retries++
+		g.lock.Unlock()
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// Check if the circuit breaker lets requests through
+func (g *endpointGuard) isAvailable() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return !time.Now().Before(g.openUntil)
+}
+
+// Count a request that was moved to another endpoint
+func (g *endpointGuard) recordFailover() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.stats.Failovers++
+}
+
+// Get a snapshot of the endpoint's counters
+func (g *endpointGuard) getStats() ClientEndpointStats {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	stats := g.stats
+	stats.Endpoint = strings.ToLower(strings.ReplaceAll(g.name, " ", "_"))
+	return stats
+}
+
+// Get the share of requests that failed since the last call
+func (g *endpointGuard) takeErrorRate() float64 {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	requests := g.stats.Requests - g.scoredRequests
+	failures := g.stats.Failures - g.scoredFailures
+	g.scoredRequests = g.stats.Requests
+	g.scoredFailures = g.stats.Failures
+	if requests == 0 {
+		return 0
+	}
+	return float64(failures) / float64(requests)
+}
+
+func (g *endpointGuard) recordSuccess() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.consecutiveFailures = 0
+	g.openUntil = time.Time{}
+}
+
+// Count a failed request, opening the circuit once too many fail in a row
+func (g *endpointGuard) recordFailure() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.stats.Failures++
+	g.consecutiveFailures++
+	if g.policy.CircuitBreakerThreshold > 0 && g.consecutiveFailures >= g.policy.CircuitBreakerThreshold {
+		g.openUntil = time.Now().Add(g.policy.CircuitBreakerCooldown)
+		g.stats.CircuitOpens++
+	}
+}
+
+// Block until the token bucket allows another request
+func (g *endpointGuard) waitForRateLimit() {
+	if g.policy.RateLimit == 0 {
+		return
+	}
+	rate := float64(g.policy.RateLimit)
+
+	g.lock.Lock()
+	now := time.Now()
+	g.tokens += now.Sub(g.lastRefill).Seconds() * rate
+	if g.tokens > rate {
+		g.tokens = rate
+	}
+	g.lastRefill = now
+	g.tokens--
+	var wait time.Duration
+	if g.tokens < 0 {
+		wait = time.Duration(-g.tokens / rate * float64(time.Second))
+		g.stats.Throttled++
+	}
+	g.lock.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// Returns true if the error was a connection failure
+func isDisconnectError(err error) bool {
+	return strings.Contains(err.Error(), "dial tcp")
+}
+
+// Returns true if the request timed out, in which case it may still have reached the client
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Returns true if the error is likely transient (timeouts, dropped connections, 5xx responses and rate limits)
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return isRetryableStatus(httpErr.StatusCode)
+	}
+	var beaconErr *client.HTTPStatusError
+	if errors.As(err, &beaconErr) {
+		return isRetryableStatus(beaconErr.StatusCode)
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == rpcLimitExceededCode
+	}
+	return false
+}
+
+// Returns true if the HTTP status means the server is overloaded or failing rather than rejecting the request
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// The last known head of a client endpoint
+type ClientEndpointHead struct {
+	Endpoint        string
+	HeadNumber      uint64
+	HeadTime        time.Time
+	FinalizedEpoch  uint64
+	IsStalled       bool
+	FinalityStalled bool
+}
+
+// Get the display name of the endpoint at the given priority
+func getEndpointName(priority int) string {
+	switch priority {
+	case 0:
+		return "Primary"
+	case 1:
+		return "Fallback"
+	default:
+		return fmt.Sprintf("Fallback %d", priority)
+	}
+}
+
+// Split a comma-separated list of URLs, skipping blank entries
+func splitUrlList(urls string) []string {
+	list := []string{}
+	for _, url := range strings.Split(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			list = append(list, url)
+		}
+	}
+	return list
+}
+
+// Score an endpoint from 0 (unusable) to 100 based on its sync status, how far its head lags behind the best endpoint, and its recent error rate
+func getHealthScore(status api.ClientStatus, headLag uint64, errorRate float64) float64 {
+	if !status.IsWorking || !status.IsSynced {
+		return 0
+	}
+	lagPenalty := float64(headLag) * headLagPenalty
+	if lagPenalty > maxHeadLagPenalty {
+		lagPenalty = maxHeadLagPenalty
+	}
+	return maxHealthScore - lagPenalty - errorRate*maxErrorRatePenalty
+}
+
+// Get the index of the endpoint requests should go to first: the highest priority one that scores close to the best.
+// Returns -1 if none of them are healthy.
+func getPreferredEndpoint(scores []float64) int {
+	best := float64(0)
+	for _, score := range scores {
+		if score > best {
+			best = score
+		}
+	}
+	if best == 0 {
+		return -1
+	}
+	for i, score := range scores {
+		if score > 0 && score >= best-healthScoreTolerance {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stader-labs/stader-node/shared/services/beacon/client"
+)
+
+// A timeout from the network stack
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// A JSON-RPC error response
+type testRpcError struct {
+	code    int
+	message string
+}
+
+func (e testRpcError) Error() string  { return e.message }
+func (e testRpcError) ErrorCode() int { return e.code }
+
+func TestIsRetryableError(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"network timeout", &url.Error{Op: "Post", URL: "http://ec", Err: timeoutError{}}, true},
+		{"connection reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"dropped response", fmt.Errorf("Could not get validators: %w", io.ErrUnexpectedEOF), true},
+		{"EC rate limit", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, true},
+		{"EC server error", rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}, true},
+		{"EC bad request", rpc.HTTPError{StatusCode: 400, Status: "400 Bad Request"}, false},
+		{"EC limit exceeded", testRpcError{code: -32005, message: "request rate limited"}, true},
+		{"EC rejected transaction", testRpcError{code: -32000, message: "replacement transaction underpriced"}, false},
+		{"EC error mentioning a timeout", testRpcError{code: -32000, message: "execution timeout"}, false},
+		{"BC server error", fmt.Errorf("Could not get head: %w", &client.HTTPStatusError{Message: "Could not get head", StatusCode: 500}), true},
+		{"BC not found", &client.HTTPStatusError{Message: "Could not get validator", StatusCode: 404}, false},
+		{"plain error mentioning a timeout", errors.New("timeout waiting for http status 500"), false},
+		{"caller gave up", fmt.Errorf("Post: %w", context.DeadlineExceeded), false},
+		{"caller cancelled", context.Canceled, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if isRetryableError(test.err) != test.expected {
+				t.Fatalf("expected retryable to be %t", test.expected)
+			}
+		})
+	}
+
+}
+
+func TestEndpointGuardRetries(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		err              error
+		expectedRequests uint64
+		expectedFailover bool
+	}{
+		{"success", nil, 1, false},
+		{"rejected", testRpcError{code: -32000, message: "nonce too low"}, 1, false},
+		{"transient", rpc.HTTPError{StatusCode: 503}, 3, true},
+		{"disconnected", errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), 1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guard := newEndpointGuard("Primary", &ClientPolicy{MaxRetries: 2, RetryBackoff: time.Millisecond})
+			shouldFailover, err := guard.run(func() error {
+				return test.err
+			})
+			if (err == nil) != (test.err == nil) {
+				t.Fatalf("got error %v, expected %v", err, test.err)
+			}
+			if shouldFailover != test.expectedFailover {
+				t.Fatalf("got failover %t, expected %t", shouldFailover, test.expectedFailover)
+			}
+			stats := guard.getStats()
+			if stats.Requests != test.expectedRequests {
+				t.Fatalf("got %d requests, expected %d", stats.Requests, test.expectedRequests)
+			}
+			if stats.Retries != test.expectedRequests-1 {
+				t.Fatalf("got %d retries, expected %d", stats.Retries, test.expectedRequests-1)
+			}
+		})
+	}
+
+}
+
+func TestEndpointGuardCircuitBreaker(t *testing.T) {
+	guard := newEndpointGuard("Primary", &ClientPolicy{CircuitBreakerThreshold: 2, CircuitBreakerCooldown: time.Hour})
+	unavailable := func() error {
+		return rpc.HTTPError{StatusCode: 503}
+	}
+
+	// One failure isn't enough to open it
+	guard.run(unavailable)
+	if !guard.isAvailable() {
+		t.Fatal("expected the circuit to stay closed after one failure")
+	}
+
+	// A success in between starts the count again
+	guard.run(func() error { return nil })
+	guard.run(unavailable)
+	if !guard.isAvailable() {
+		t.Fatal("expected a success to reset the failure count")
+	}
+
+	guard.run(unavailable)
+	if guard.isAvailable() {
+		t.Fatal("expected the circuit to open after two failures in a row")
+	}
+	if stats := guard.getStats(); stats.CircuitOpens != 1 || stats.Failures != 3 {
+		t.Fatalf("got %d circuit opens and %d failures, expected 1 and 3", stats.CircuitOpens, stats.Failures)
+	}
+
+	// It closes again once a request goes through
+	guard.run(func() error { return nil })
+	if !guard.isAvailable() {
+		t.Fatal("expected a success to close the circuit")
+	}
+}
+
+func TestEndpointGuardRateLimit(t *testing.T) {
+	guard := newEndpointGuard("Primary", &ClientPolicy{RateLimit: 20})
+
+	// The bucket starts full, so a burst up to the limit isn't throttled
+	for i := 0; i < 20; i++ {
+		guard.waitForRateLimit()
+	}
+	if throttled := guard.getStats().Throttled; throttled != 0 {
+		t.Fatalf("expected the first 20 requests not to be throttled, got %d", throttled)
+	}
+
+	// The next one waits for a token to refill
+	start := time.Now()
+	guard.waitForRateLimit()
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Fatalf("expected to wait about 50ms for a token, waited %s", elapsed)
+	}
+	if throttled := guard.getStats().Throttled; throttled != 1 {
+		t.Fatalf("expected 1 throttled request, got %d", throttled)
+	}
+
+	// No limit means no waiting
+	unlimited := newEndpointGuard("Fallback", &ClientPolicy{})
+	for i := 0; i < 100; i++ {
+		unlimited.waitForRateLimit()
+	}
+	if throttled := unlimited.getStats().Throttled; throttled != 0 {
+		t.Fatalf("expected no throttling without a rate limit, got %d", throttled)
+	}
+}
@@ -32,6 +32,12 @@ type FallbackNormalConfig struct {
 
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+
+	// Comma-separated URLs of further Execution Client HTTP endpoints, in order of priority
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// Comma-separated URLs of further Beacon Node HTTP endpoints, in order of priority
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
 }
 
 // Configuration for fallback Prysm
@@ -44,6 +50,12 @@ type FallbackPrysmConfig struct {
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
 
+	// Comma-separated URLs of further Execution Client HTTP endpoints, in order of priority
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// Comma-separated URLs of further Beacon Node HTTP endpoints, in order of priority
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
+
 	// The URL of the JSON-RPC endpoint for the Validator client
 	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
 }
@@ -76,6 +88,30 @@ func NewFallbackNormalConfig(cfg *StaderConfig) *FallbackNormalConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          "A comma-separated list of further Execution client HTTP API endpoints to use if the primary and fallback are unavailable, in order of priority. The Stadernode sends requests to the healthiest of them, based on their sync status, how far they lag behind the others and how many requests fail.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of further Beacon API endpoints to use if the primary and fallback are unavailable, in order of priority. The Stadernode sends requests to the healthiest of them, based on their sync status, how far they lag behind the others and how many requests fail.\n\nNOTE: These are only used by the Stadernode; your Validator client only uses the primary and fallback.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -108,6 +144,30 @@ func NewFallbackPrysmConfig(cfg *StaderConfig) *FallbackPrysmConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          "A comma-separated list of further Execution client HTTP API endpoints to use if the primary and fallback are unavailable, in order of priority. The Stadernode sends requests to the healthiest of them, based on their sync status, how far they lag behind the others and how many requests fail.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of further Beacon API endpoints to use if the primary and fallback are unavailable, in order of priority. The Stadernode sends requests to the healthiest of them, based on their sync status, how far they lag behind the others and how many requests fail.\n\nNOTE: These are only used by the Stadernode; your Validator client only uses the primary and fallback.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		JsonRpcUrl: config.Parameter{
 			ID:                   "jsonRpcUrl",
 			Name:                 "Beacon Node JSON-RPC URL",
@@ -127,6 +187,8 @@ func (cfg *FallbackNormalConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -135,6 +197,8 @@ func (cfg *FallbackPrysmConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 		&cfg.JsonRpcUrl,
 	}
 }
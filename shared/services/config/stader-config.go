@@ -81,6 +81,21 @@ type StaderConfig struct {
 	UseFallbackClients config.Parameter `yaml:"useFallbackClients,omitempty"`
 	ReconnectDelay     config.Parameter `yaml:"reconnectDelay,omitempty"`
 
+	// Client request policy settings
+	ClientMaxRetries              config.Parameter `yaml:"clientMaxRetries,omitempty"`
+	ClientRetryBackoff            config.Parameter `yaml:"clientRetryBackoff,omitempty"`
+	ClientRateLimit               config.Parameter `yaml:"clientRateLimit,omitempty"`
+	ClientCircuitBreakerThreshold config.Parameter `yaml:"clientCircuitBreakerThreshold,omitempty"`
+
+	// Execution client consistency settings
+	EcConsistencyCheck             config.Parameter `yaml:"ecConsistencyCheck,omitempty"`
+	EcConsistencyMaxDepth          config.Parameter `yaml:"ecConsistencyMaxDepth,omitempty"`
+	EcConsistencyBlockTransactions config.Parameter `yaml:"ecConsistencyBlockTransactions,omitempty"`
+
+	// Stuck client detection settings
+	ClientHeadStallThreshold  config.Parameter `yaml:"clientHeadStallThreshold,omitempty"`
+	ClientFinalityStallEpochs config.Parameter `yaml:"clientFinalityStallEpochs,omitempty"`
+
 	// Consensus client settings
 	ConsensusClientMode     config.Parameter `yaml:"consensusClientMode,omitempty"`
 	ConsensusClient         config.Parameter `yaml:"consensusClient,omitempty"`
@@ -251,6 +266,114 @@ func NewStaderConfig(staderDir string, isNativeMode bool) *StaderConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		ClientMaxRetries: config.Parameter{
+			ID:                   "clientMaxRetries",
+			Name:                 "Client Max Retries",
+			Description:          "The number of times a request to an Execution or Consensus client is retried after a transient failure (such as a timeout, a 5xx response or a rate limit) before switching to the fallback client.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientRetryBackoff: config.Parameter{
+			ID:                   "clientRetryBackoff",
+			Name:                 "Client Retry Backoff",
+			Description:          "The delay before the first retry of a failed client request. It doubles with every further retry. An example format is \"500ms\".",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "500ms"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientRateLimit: config.Parameter{
+			ID:                   "clientRateLimit",
+			Name:                 "Client Rate Limit",
+			Description:          "The maximum number of requests per second sent to each Execution and Consensus client. Useful for hosted providers with request quotas. Use 0 for no limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientCircuitBreakerThreshold: config.Parameter{
+			ID:                   "clientCircuitBreakerThreshold",
+			Name:                 "Client Circuit Breaker Threshold",
+			Description:          "The number of consecutive failed requests after which a client is skipped in favor of the fallback for the Reconnect Delay. Use 0 to disable.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcConsistencyCheck: config.Parameter{
+			ID:                   "ecConsistencyCheck",
+			Name:                 "Check Execution Client Consistency",
+			Description:          "Enable this to periodically compare the chain ID, head block and finalized block of all of your Execution clients, and warn if they disagree. This can reveal a client that is stuck on a stale or minority fork. It requires at least one fallback client.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcConsistencyMaxDepth: config.Parameter{
+			ID:                   "ecConsistencyMaxDepth",
+			Name:                 "Execution Client Divergence Depth",
+			Description:          "The number of blocks below the lowest head at which your Execution clients must agree. Disagreements above this depth are treated as normal short reorgs.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(2)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcConsistencyBlockTransactions: config.Parameter{
+			ID:                   "ecConsistencyBlockTransactions",
+			Name:                 "Block Transactions On Divergence",
+			Description:          "Enable this to refuse to send transactions while your Execution clients disagree. Only used if the consistency check is enabled.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientHeadStallThreshold: config.Parameter{
+			ID:                   "clientHeadStallThreshold",
+			Name:                 "Client Head Stall Threshold",
+			Description:          "How long an Execution or Consensus client's head may go without a new block before the client is considered stuck, even if it reports that it is synced. An example format is \"5m\".",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "5m"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientFinalityStallEpochs: config.Parameter{
+			ID:                   "clientFinalityStallEpochs",
+			Name:                 "Client Finality Stall Epochs",
+			Description:          "How many epochs a Consensus client's finalized checkpoint may fall behind the current epoch before finality is considered stalled. Normally it is 2 epochs behind. A client whose finality stalls while another client's doesn't is considered stuck.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ConsensusClientMode: config.Parameter{
 			ID:                   "consensusClientMode",
 			Name:                 "Consensus Client Mode",
@@ -536,6 +659,15 @@ func (cfg *StaderConfig) GetParameters() []*config.Parameter {
 		&cfg.ExecutionClient,
 		&cfg.UseFallbackClients,
 		&cfg.ReconnectDelay,
+		&cfg.ClientMaxRetries,
+		&cfg.ClientRetryBackoff,
+		&cfg.ClientRateLimit,
+		&cfg.ClientCircuitBreakerThreshold,
+		&cfg.EcConsistencyCheck,
+		&cfg.EcConsistencyMaxDepth,
+		&cfg.EcConsistencyBlockTransactions,
+		&cfg.ClientHeadStallThreshold,
+		&cfg.ClientFinalityStallEpochs,
 		&cfg.ConsensusClientMode,
 		&cfg.ConsensusClient,
 		&cfg.ExternalConsensusClient,
@@ -27,6 +27,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mitchellh/go-homedir"
@@ -48,6 +49,8 @@ const (
 	MerkleProofsFormat          string = "cycle-%s-%d.json"
 	FeeRecipientFilename        string = "stader-fee-recipient.txt"
 	NativeFeeRecipientFilename  string = "stader-fee-recipient-env.txt"
+	KeymanagerTokenFilename     string = "keymanager-token.txt"
+	GraffitiFilename            string = "graffiti.txt"
 )
 
 //go:embed prod-presign-public-key.txt
@@ -95,9 +98,54 @@ type StaderNodeConfig struct {
 	// Max tx fee for a single tx override
 	TxFeeCap config.Parameter `yaml:"txFeeCap,omitempty"`
 
+	// Max total tx fees over a rolling 24 hours
+	DailyGasBudget config.Parameter `yaml:"dailyGasBudget,omitempty"`
+
+	// Number of blocks a transaction must be buried under before it's considered final
+	TxConfirmations config.Parameter `yaml:"txConfirmations,omitempty"`
+
+	// Minutes to wait for a transaction to be confirmed
+	TxWaitTimeout config.Parameter `yaml:"txWaitTimeout,omitempty"`
+
+	// How long a transaction can be missing from the Execution client before it's considered dropped
+	TxDropTimeout config.Parameter `yaml:"txDropTimeout,omitempty"`
+
+	// RPC that node transactions are sent to instead of the Execution client
+	TxSubmissionUrl config.Parameter `yaml:"txSubmissionUrl,omitempty"`
+
+	// When to send transactions to the Execution client if the submission RPC fails
+	TxSubmissionFallback config.Parameter `yaml:"txSubmissionFallback,omitempty"`
+
+	// Clef-compatible signer that holds the node account key
+	ExternalSignerUrl config.Parameter `yaml:"externalSignerUrl,omitempty"`
+
+	// The node account in the external signer
+	ExternalSignerAddress config.Parameter `yaml:"externalSignerAddress,omitempty"`
+
+	// The node account address for a machine without the node wallet
+	WatchOnlyNodeAddress config.Parameter `yaml:"watchOnlyNodeAddress,omitempty"`
+
+	// Web3Signer that holds the validator keys instead of the local keystores
+	Web3SignerUrl config.Parameter `yaml:"web3SignerUrl,omitempty"`
+
+	// Load and remove validator keys through the validator client's keymanager API instead of restarting it
+	KeymanagerApi config.Parameter `yaml:"keymanagerApi,omitempty"`
+
+	// The port of the validator client's keymanager API
+	KeymanagerApiPort config.Parameter `yaml:"keymanagerApiPort,omitempty"`
+
+	// Template for the graffiti of each validator, applied through the keymanager API
+	GraffitiTemplate config.Parameter `yaml:"graffitiTemplate,omitempty"`
+
+	// Gas limit the validator client registers with builders
+	ValidatorGasLimit config.Parameter `yaml:"validatorGasLimit,omitempty"`
+
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// Number of epochs to watch for doppelgangers before starting the validator client
+	DoppelgangerCheckEpochs config.Parameter `yaml:"doppelgangerCheckEpochs,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -219,6 +267,187 @@ func NewStadernodeConfig(cfg *StaderConfig) *StaderNodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		DailyGasBudget: config.Parameter{
+			ID:                   "dailyGasBudget",
+			Name:                 "Daily Gas Budget",
+			Description:          "The max total fee (in eth) the node wallet may commit to transactions over a rolling 24 hours. Each transaction counts its worst case fee (max fee times gas limit) when it is signed. Use 0 for no limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxConfirmations: config.Parameter{
+			ID:                   "txConfirmations",
+			Name:                 "Tx Confirmations",
+			Description:          "The number of blocks a transaction must be included under (counting its own block) before the node treats it as final. Higher values protect against chain reorganizations at the cost of waiting longer.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxWaitTimeout: config.Parameter{
+			ID:                   "txWaitTimeout",
+			Name:                 "Tx Wait Timeout",
+			Description:          "The number of minutes to wait for a transaction to be confirmed before giving up on it. The transaction may still be mined afterwards; you can check it with `stader-cli node tx list`. Use 0 to wait indefinitely.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(30)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxDropTimeout: config.Parameter{
+			ID:                   "txDropTimeout",
+			Name:                 "Tx Drop Timeout",
+			Description:          "The number of seconds a transaction can be missing from your Execution client before it's considered dropped and the wait gives up on it. Raise it if your client often evicts and re-receives transactions while the network is busy. Use 0 to keep waiting for the full Tx Wait Timeout.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxSubmissionUrl: config.Parameter{
+			ID:                   "txSubmissionUrl",
+			Name:                 "Tx Submission RPC URL",
+			Description:          "The URL of a private transaction RPC, such as Flashbots Protect, that the node's transactions are sent to instead of the public mempool. This protects claims and deposits from being front-run. Everything else, including tracking the transactions, still uses your Execution client.\n\nLeave blank to send transactions through your Execution client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxSubmissionFallback: config.Parameter{
+			ID:                   "txSubmissionFallback",
+			Name:                 "Tx Submission Fallback",
+			Description:          "What to do when the Tx Submission RPC can't take a transaction.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.TxSubmissionFallback_Unreachable},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "When Unreachable",
+				Description: "Send the transaction through your Execution client if the RPC can't be reached or times out, but not if it rejects the transaction.",
+				Value:       config.TxSubmissionFallback_Unreachable,
+			}, {
+				Name:        "Always",
+				Description: "Send the transaction through your Execution client whenever the RPC fails to take it.",
+				Value:       config.TxSubmissionFallback_Always,
+			}, {
+				Name:        "Never",
+				Description: "Never send the transaction to the public mempool; fail instead.",
+				Value:       config.TxSubmissionFallback_Never,
+			}},
+		},
+
+		ExternalSignerUrl: config.Parameter{
+			ID:                   "externalSignerUrl",
+			Name:                 "External Signer URL",
+			Description:          "The JSON-RPC URL of a Clef-compatible external signer that holds the node account key. When it's set, transactions and messages from the node account are signed by the signer (`account_signTransaction` and `account_signData`) and the node account key never touches this machine. The node wallet is still used for your validator keys.\n\nThe daemon signs a fixed message once each time it starts to learn the node account's public key, and signs a node diversity report once a day, so Clef will ask you to approve those unless one of its rules does.\n\nLeave blank to sign with the key derived from the node wallet.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExternalSignerAddress: config.Parameter{
+			ID:                   "externalSignerAddress",
+			Name:                 "External Signer Address",
+			Description:          "The address of the node account in the external signer. Leave blank if the signer only holds one account.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchOnlyNodeAddress: config.Parameter{
+			ID:                   "watchOnlyNodeAddress",
+			Name:                 "Watch-Only Node Address",
+			Description:          "The node account address, for an online machine that doesn't hold the node wallet. When it's set and there's no node wallet, node account transactions can be exported with `--offline-export`, signed on the machine that holds the wallet, and broadcast from here with `stader-cli node broadcast`.\n\nLeave blank if the node wallet is on this machine.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerUrl: config.Parameter{
+			ID:                   "web3SignerUrl",
+			Name:                 "Web3Signer URL",
+			Description:          "The URL of a Web3Signer instance with its keymanager API enabled. When it's set, new validator keys are imported into Web3Signer instead of being written to the local keystores, your validator client signs through it, and presigned exits are signed by it.\n\nLeave blank to keep validator keys in the local keystores.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"WEB3SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApi: config.Parameter{
+			ID:                   "keymanagerApi",
+			Name:                 "Enable Keymanager API",
+			Description:          "Enable the keymanager API of your validator client, so new and recovered validator keys are loaded without restarting it. Restarting the validator client makes every one of your validators miss duties while it starts back up.\n\nIf the API can't be reached, the validator client is restarted instead.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"KEYMANAGER_API_ENABLED"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiPort: config.Parameter{
+			ID:                   "keymanagerApiPort",
+			Name:                 "Keymanager API Port",
+			Description:          "The port your validator client serves its keymanager API on. It's only reachable from the Stader containers.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(5062)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GraffitiTemplate: config.Parameter{
+			ID:                   "graffitiTemplate",
+			Name:                 "Graffiti Template",
+			Description:          "A graffiti for each of your validators, applied through the keymanager API. It can include {operator} for your operator name, {ec}, {cc} and {vc} for your client versions, and {index} for the validator index. It's cut to 32 bytes.\n\nWithout the keymanager API, the validator client uses it for every validator, without the {index}, after it restarts.\n\nLeave blank to use the Custom Graffiti for every validator.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ValidatorGasLimit: config.Parameter{
+			ID:                   "validatorGasLimit",
+			Name:                 "Validator Gas Limit",
+			Description:          "The gas limit your validator client registers with MEV-Boost builders for blocks it proposes. Use 0 to keep your validator client's default.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_GAS_LIMIT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ArchiveECUrl: config.Parameter{
 			ID:                   "archiveECUrl",
 			Name:                 "Archive-Mode EC URL",
@@ -231,6 +460,18 @@ func NewStadernodeConfig(cfg *StaderConfig) *StaderNodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		DoppelgangerCheckEpochs: config.Parameter{
+			ID:                   "doppelgangerCheckEpochs",
+			Name:                 "Doppelganger Check Epochs",
+			Description:          "The number of epochs `stader-cli service start` watches the Beacon chain for your validator keys before it starts the validator client. If any of them are seen attesting, another machine is already running them and the validator client will not be started, since running them twice would get them slashed.\n\nEach epoch takes about 6.4 minutes. Use 0 to disable the check. It is skipped when the validator client's own Doppelganger Protection is enabled, since that does the same thing.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(2)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		beaconChainUrl: map[config.Network]string{
 			config.Network_Mainnet: "https://beaconcha.in",
 			config.Network_Prater:  "https://prater.beaconcha.in",
@@ -289,7 +530,22 @@ func (cfg *StaderNodeConfig) GetParameters() []*config.Parameter {
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.TxFeeCap,
+		&cfg.DailyGasBudget,
+		&cfg.TxConfirmations,
+		&cfg.TxWaitTimeout,
+		&cfg.TxDropTimeout,
+		&cfg.TxSubmissionUrl,
+		&cfg.TxSubmissionFallback,
+		&cfg.ExternalSignerUrl,
+		&cfg.ExternalSignerAddress,
+		&cfg.WatchOnlyNodeAddress,
+		&cfg.Web3SignerUrl,
+		&cfg.KeymanagerApi,
+		&cfg.KeymanagerApiPort,
+		&cfg.GraffitiTemplate,
+		&cfg.ValidatorGasLimit,
 		&cfg.ArchiveECUrl,
+		&cfg.DoppelgangerCheckEpochs,
 	}
 }
 
@@ -380,6 +636,40 @@ func (cfg *StaderNodeConfig) GetValidatorKeychainPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators")
 }
 
+// Get how long to wait for a transaction to be confirmed, or 0 to wait indefinitely
+func (cfg *StaderNodeConfig) GetTxWaitTimeout() time.Duration {
+	return time.Duration(cfg.TxWaitTimeout.Value.(uint64)) * time.Minute
+}
+
+// Get how long a transaction can be missing from the Execution client before it's considered dropped, or 0 to never give up on it
+func (cfg *StaderNodeConfig) GetTxDropTimeout() time.Duration {
+	return time.Duration(cfg.TxDropTimeout.Value.(uint64)) * time.Second
+}
+
+func (cfg *StaderNodeConfig) GetGasSpendPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "gas-spend.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "gas-spend.json")
+}
+
+func (cfg *StaderNodeConfig) GetTxRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "transactions.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "transactions.json")
+}
+
+func (cfg *StaderNodeConfig) GetTxJournalPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "tx-journal.jsonl")
+	}
+
+	return filepath.Join(DaemonDataPath, "tx-journal.jsonl")
+}
+
 func (config *StaderNodeConfig) GetGuardianStatePath() string {
 	if config.parent.IsNativeMode {
 		return filepath.Join(config.DataPath.Value.(string), GuardianFolder, "state.yml")
@@ -404,6 +694,14 @@ func (cfg *StaderNodeConfig) GetCustomKeyPasswordFilePath() string {
 	return filepath.Join(DaemonDataPath, "custom-key-passwords")
 }
 
+func (cfg *StaderNodeConfig) GetImportedKeyPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "imported-keys")
+	}
+
+	return filepath.Join(DaemonDataPath, "imported-keys")
+}
+
 func (cfg *StaderNodeConfig) GetStadernodeContainerTag() string {
 	return stadernodeTag
 }
@@ -465,6 +763,34 @@ func (cfg *StaderNodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+// Get the URL of the validator client's keymanager API
+func (cfg *StaderNodeConfig) GetKeymanagerApiUrl() string {
+	port := cfg.KeymanagerApiPort.Value.(uint16)
+	if cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", port)
+	}
+
+	return fmt.Sprintf("http://%s_%s:%d", cfg.ProjectName.Value.(string), ValidatorContainerName, port)
+}
+
+// Get the file holding the bearer token of the validator client's keymanager API
+func (cfg *StaderNodeConfig) GetKeymanagerTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", KeymanagerTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", KeymanagerTokenFilename)
+}
+
+// Get the file holding the rendered graffiti template the validator client uses when its keymanager API is disabled
+func (cfg *StaderNodeConfig) GetGraffitiFilePath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", GraffitiFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", GraffitiFilename)
+}
+
 func (cfg *StaderNodeConfig) GetClaimData(cycles []*big.Int) ([]*big.Int, []*big.Int, [][][32]byte, error) {
 	// data to pass to socializing pool contract
 	amountSd := []*big.Int{}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// How often the Execution clients are compared when the consistency check is enabled
+const ecConsistencyCheckInterval time.Duration = time.Minute
+
+// The chain as seen by a single Execution client
+type ecChainView struct {
+	endpoint  *ecEndpoint
+	chainId   *big.Int
+	head      *types.Header
+	finalized *types.Header
+}
+
+// Compare the chain ID, finalized block and head block of every ready Execution client.
+// Returns an error describing the problem if any of them disagree; clients that can't be queried are logged and left out of the comparison.
+func (p *ExecutionClientManager) CheckConsistency(ctx context.Context) error {
+	return p.checkConsistency(ctx, p.getReadyEndpoints())
+}
+
+// Compare the given Execution clients
+func (p *ExecutionClientManager) checkConsistency(ctx context.Context, endpoints []*ecEndpoint) error {
+
+	// Get each client's view of the chain
+	views := []ecChainView{}
+	for _, endpoint := range endpoints {
+		view, err := getEcChainView(ctx, endpoint)
+		if err != nil {
+			p.logger.Printlnf("WARNING: Could not get the chain state of the %s Execution client for the consistency check (%s)", endpoint.name, err.Error())
+			continue
+		}
+		views = append(views, view)
+	}
+	if len(views) < 2 {
+		return nil
+	}
+	reference := views[0]
+
+	// Check the chain IDs
+	for _, view := range views[1:] {
+		if view.chainId.Cmp(reference.chainId) != 0 {
+			return fmt.Errorf("the %s Execution client is on chain ID %s but the %s Execution client is on chain ID %s", view.endpoint.name, view.chainId, reference.endpoint.name, reference.chainId)
+		}
+	}
+
+	// Check the finalized blocks, which must never differ
+	var lowestFinalized *big.Int
+	for _, view := range views {
+		if view.finalized != nil && (lowestFinalized == nil || view.finalized.Number.Cmp(lowestFinalized) < 0) {
+			lowestFinalized = view.finalized.Number
+		}
+	}
+	if lowestFinalized != nil {
+		if err := p.compareBlockHashes(ctx, views, lowestFinalized); err != nil {
+			return fmt.Errorf("finalized chains differ: %w", err)
+		}
+	}
+
+	// Check the heads, ignoring recent blocks that could still be reorged
+	lowestHead := reference.head.Number
+	for _, view := range views[1:] {
+		if view.head.Number.Cmp(lowestHead) < 0 {
+			lowestHead = view.head.Number
+		}
+	}
+	checkNumber := new(big.Int).Sub(lowestHead, new(big.Int).SetUint64(p.consistencyMaxDepth))
+	if checkNumber.Sign() > 0 {
+		if err := p.compareBlockHashes(ctx, views, checkNumber); err != nil {
+			return fmt.Errorf("chains diverged more than %d blocks deep: %w", p.consistencyMaxDepth, err)
+		}
+	}
+
+	return nil
+
+}
+
+// Get the result of the last consistency check, running a new one against the given endpoints if it's out of date
+func (p *ExecutionClientManager) getConsistencyError(ctx context.Context, endpoints []*ecEndpoint) error {
+	p.consistencyLock.Lock()
+	defer p.consistencyLock.Unlock()
+
+	if time.Since(p.lastConsistencyCheck) < ecConsistencyCheckInterval {
+		return p.consistencyError
+	}
+	return p.updateConsistencyError(ctx, endpoints)
+}
+
+// Run a consistency check and store its result; the caller must hold consistencyLock
+func (p *ExecutionClientManager) updateConsistencyError(ctx context.Context, endpoints []*ecEndpoint) error {
+	err := p.checkConsistency(ctx, endpoints)
+	if err != nil && p.consistencyError == nil {
+		p.logger.Printlnf("WARNING: Execution clients disagree: %s", err.Error())
+	} else if err == nil && p.consistencyError != nil {
+		p.logger.Printlnf("Execution clients agree again.")
+	}
+	p.consistencyError = err
+	p.lastConsistencyCheck = time.Now()
+	return err
+}
+
+// Check the Execution clients in the background every ecConsistencyCheckInterval until the context is cancelled, so a divergence is
+// logged when it happens instead of when the next transaction is sent
+func (p *ExecutionClientManager) StartConsistencyChecks(ctx context.Context) {
+	if !p.consistencyCheck {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(ecConsistencyCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				endpoints := p.getReadyEndpoints()
+				p.consistencyLock.Lock()
+				_ = p.updateConsistencyError(ctx, endpoints)
+				p.consistencyLock.Unlock()
+			}
+		}
+	}()
+}
+
+// Get a snapshot of the endpoints that are currently ready
+func (p *ExecutionClientManager) getReadyEndpoints() []*ecEndpoint {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	return p.getReadyEndpointsLocked()
+}
+
+// Get the endpoints that are currently ready; the caller must hold statusLock
+func (p *ExecutionClientManager) getReadyEndpointsLocked() []*ecEndpoint {
+	endpoints := []*ecEndpoint{}
+	for _, endpoint := range p.endpoints {
+		if endpoint.ready {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// Get a client's chain ID, head and finalized block
+func getEcChainView(ctx context.Context, endpoint *ecEndpoint) (ecChainView, error) {
+	view := ecChainView{
+		endpoint: endpoint,
+	}
+
+	var err error
+	view.chainId, err = endpoint.client.ChainID(ctx)
+	if err != nil {
+		return ecChainView{}, fmt.Errorf("error getting chain ID: %w", err)
+	}
+	view.head, err = endpoint.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return ecChainView{}, fmt.Errorf("error getting head block: %w", err)
+	}
+
+	// Clients that don't support the finalized tag are only compared by their heads
+	var finalized *types.Header
+	if err := endpoint.rpcClient.CallContext(ctx, &finalized, "eth_getBlockByNumber", "finalized", false); err == nil {
+		view.finalized = finalized
+	}
+
+	return view, nil
+}
+
+// Make sure every client has the same block at the given height.
+// Clients that can't return the block are logged and skipped, so a failed request isn't reported as a divergence.
+func (p *ExecutionClientManager) compareBlockHashes(ctx context.Context, views []ecChainView, number *big.Int) error {
+	var referenceHash common.Hash
+	var referenceName string
+	for _, view := range views {
+		header, err := view.endpoint.client.HeaderByNumber(ctx, number)
+		if err != nil {
+			p.logger.Printlnf("WARNING: Could not get block %s from the %s Execution client for the consistency check (%s)", number, view.endpoint.name, err.Error())
+			continue
+		}
+		if referenceName == "" {
+			referenceHash = header.Hash()
+			referenceName = view.endpoint.name
+			continue
+		}
+		if header.Hash() != referenceHash {
+			return fmt.Errorf("block %s is %s on the %s Execution client but %s on the %s Execution client", number, header.Hash().Hex(), view.endpoint.name, referenceHash.Hex(), referenceName)
+		}
+	}
+	return nil
+}
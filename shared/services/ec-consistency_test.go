@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/fatih/color"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// A mock Execution client on the given chain that answers every block query with the same block
+func newMockChain(t *testing.T, chainId string, block string) *mockRpc {
+	header := &types.Header{
+		Number:     big.NewInt(100),
+		Time:       uint64(time.Now().Unix()),
+		Difficulty: big.NewInt(0),
+		Extra:      []byte(block),
+	}
+	return newMockRpc(t, map[string]interface{}{
+		"net_version":          "1",
+		"eth_chainId":          chainId,
+		"eth_syncing":          false,
+		"eth_getBlockByNumber": header,
+	})
+}
+
+func newTestEcEndpoint(t *testing.T, name string, url string, policy *ClientPolicy) *ecEndpoint {
+	rpcClient, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(rpcClient.Close)
+	return &ecEndpoint{
+		name:      name,
+		url:       url,
+		client:    ethclient.NewClient(rpcClient),
+		rpcClient: rpcClient,
+		guard:     newEndpointGuard(name, policy),
+		ready:     true,
+	}
+}
+
+// Create a manager with the consistency check enabled for the given clients
+func newTestConsistencyManager(t *testing.T, clients ...*mockRpc) *ExecutionClientManager {
+	policy := &ClientPolicy{RetryBackoff: time.Millisecond, HeadStallThreshold: time.Hour}
+	manager := &ExecutionClientManager{
+		policy:                     policy,
+		logger:                     log.NewColorLogger(color.FgYellow),
+		consistencyCheck:           true,
+		consistencyMaxDepth:        10,
+		blockDivergentTransactions: true,
+	}
+	for i, client := range clients {
+		manager.endpoints = append(manager.endpoints, newTestEcEndpoint(t, getEndpointName(i), client.server.URL, policy))
+	}
+	return manager
+}
+
+func TestCheckConsistency(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		clients       func(t *testing.T) []*mockRpc
+		notReady      int
+		expectedError string
+	}{
+		{
+			name: "clients agree",
+			clients: func(t *testing.T) []*mockRpc {
+				return []*mockRpc{newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "a")}
+			},
+			notReady: -1,
+		},
+		{
+			name: "different chain IDs",
+			clients: func(t *testing.T) []*mockRpc {
+				return []*mockRpc{newMockChain(t, "0x1", "a"), newMockChain(t, "0x5", "a")}
+			},
+			notReady:      -1,
+			expectedError: "chain ID",
+		},
+		{
+			name: "different finalized blocks",
+			clients: func(t *testing.T) []*mockRpc {
+				return []*mockRpc{newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "b")}
+			},
+			notReady:      -1,
+			expectedError: "finalized chains differ",
+		},
+		{
+			name: "diverged client isn't ready",
+			clients: func(t *testing.T) []*mockRpc {
+				return []*mockRpc{newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "b")}
+			},
+			notReady: 2,
+		},
+		{
+			name: "client can't be reached",
+			clients: func(t *testing.T) []*mockRpc {
+				offline := newMockChain(t, "0x1", "b")
+				offline.server.Close()
+				return []*mockRpc{newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "a"), offline}
+			},
+			notReady: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := newTestConsistencyManager(t, test.clients(t)...)
+			if test.notReady >= 0 {
+				manager.endpoints[test.notReady].ready = false
+			}
+			err := manager.CheckConsistency(context.Background())
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected the clients to agree, got %v", err)
+			}
+			if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+				t.Fatalf("got %v, expected an error about %q", err, test.expectedError)
+			}
+		})
+	}
+
+}
+
+// A client that fails to return a block is skipped instead of being reported as a divergence
+func TestCompareBlockHashesSkipsFailedRequests(t *testing.T) {
+	offline := newMockChain(t, "0x1", "b")
+	manager := newTestConsistencyManager(t, newMockChain(t, "0x1", "a"), offline, newMockChain(t, "0x1", "a"))
+	views := []ecChainView{}
+	for _, endpoint := range manager.endpoints {
+		views = append(views, ecChainView{endpoint: endpoint})
+	}
+	offline.server.Close()
+
+	if err := manager.compareBlockHashes(context.Background(), views, big.NewInt(90)); err != nil {
+		t.Fatalf("expected the reachable clients to agree, got %v", err)
+	}
+}
+
+// CheckStatus holds the status lock while it flags the ready clients, so the consistency check must not take it again
+func TestCheckStatusWithConsistencyCheck(t *testing.T) {
+	manager := newTestConsistencyManager(t, newMockChain(t, "0x1", "a"), newMockChain(t, "0x1", "b"))
+	cfg := config.NewStaderConfig(t.TempDir(), false)
+
+	done := make(chan string)
+	go func() {
+		done <- manager.CheckStatus(cfg).ConsistencyError
+	}()
+	select {
+	case consistencyError := <-done:
+		if !strings.Contains(consistencyError, "finalized chains differ") {
+			t.Fatalf("got consistency error %q, expected the finalized chains to differ", consistencyError)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("CheckStatus deadlocked")
+	}
+
+	// The result is cached, so sending is blocked without querying the clients again
+	if err := manager.SendTransaction(context.Background(), newTestTransaction(t)); err == nil || !strings.Contains(err.Error(), "refusing") {
+		t.Fatalf("expected the transaction to be refused, got %v", err)
+	}
+}
@@ -22,17 +22,20 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/stader-labs/stader-node/shared/services/config"
 	"github.com/stader-labs/stader-node/shared/types/api"
@@ -43,14 +46,38 @@ import (
 
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
 type ExecutionClientManager struct {
-	primaryEcUrl    string
-	fallbackEcUrl   string
-	primaryEc       *ethclient.Client
-	fallbackEc      *ethclient.Client
+	endpoints       []*ecEndpoint
+	preferred       int
+	policy          *ClientPolicy
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
+	statusLock      sync.Mutex
+
+	// Cross-client consistency check settings and state
+	consistencyCheck           bool
+	consistencyMaxDepth        uint64
+	blockDivergentTransactions bool
+	consistencyLock            sync.Mutex
+	lastConsistencyCheck       time.Time
+	consistencyError           error
+
+	// Private RPC that transactions are sent to instead of the endpoints
+	txSubmissionClient   *ethclient.Client
+	txSubmissionFallback cfgtypes.TxSubmissionFallback
+}
+
+// How long to wait for the transaction submission RPC before treating it as unreachable
+const txSubmissionTimeout = 30 * time.Second
+
+// A single Execution client endpoint and its health
+type ecEndpoint struct {
+	name      string
+	url       string
+	client    *ethclient.Client
+	rpcClient *rpc.Client
+	guard     *endpointGuard
+	ready     bool
+	status    api.ClientStatus
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -60,7 +87,6 @@ type ecFunction func(*ethclient.Client) (interface{}, error)
 func NewExecutionClientManager(cfg *config.StaderConfig) (*ExecutionClientManager, error) {
 
 	var primaryEcUrl string
-	var fallbackEcUrl string
 
 	// Get the primary EC url
 	if cfg.IsNativeMode {
@@ -71,42 +97,72 @@ func NewExecutionClientManager(cfg *config.StaderConfig) (*ExecutionClientManage
 		primaryEcUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
 	}
 
-	// Get the fallback EC url, if applicable
+	// Get the fallback EC urls, in order of priority
+	ecUrls := []string{primaryEcUrl}
 	if cfg.UseFallbackClients.Value == true {
+		var fallbackEcUrl string
+		var additionalEcUrls string
 		if cfg.IsNativeMode {
 			fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
+			additionalEcUrls = cfg.FallbackNormal.AdditionalEcHttpUrls.Value.(string)
 		} else {
 			cc, _ := cfg.GetSelectedConsensusClient()
 			switch cc {
 			case cfgtypes.ConsensusClient_Prysm:
 				fallbackEcUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
+				additionalEcUrls = cfg.FallbackPrysm.AdditionalEcHttpUrls.Value.(string)
 			default:
 				fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
+				additionalEcUrls = cfg.FallbackNormal.AdditionalEcHttpUrls.Value.(string)
 			}
 		}
+		if fallbackEcUrl != "" {
+			ecUrls = append(ecUrls, fallbackEcUrl)
+		}
+		ecUrls = append(ecUrls, splitUrlList(additionalEcUrls)...)
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	policy, err := NewClientPolicy(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
+		return nil, err
+	}
+
+	endpoints := make([]*ecEndpoint, len(ecUrls))
+	for i, ecUrl := range ecUrls {
+		name := getEndpointName(i)
+		rpcClient, err := rpc.Dial(ecUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to %s EC at [%s]: %w", strings.ToLower(name), ecUrl, err)
+		}
+		endpoints[i] = &ecEndpoint{
+			name:      name,
+			url:       ecUrl,
+			client:    ethclient.NewClient(rpcClient),
+			rpcClient: rpcClient,
+			guard:     newEndpointGuard(name, policy),
+			ready:     true,
+		}
 	}
 
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+	// Connect to the transaction submission RPC if there is one
+	var txSubmissionClient *ethclient.Client
+	if txSubmissionUrl := cfg.StaderNode.TxSubmissionUrl.Value.(string); txSubmissionUrl != "" {
+		rpcClient, err := rpc.Dial(txSubmissionUrl)
 		if err != nil {
-			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+			return nil, fmt.Errorf("error connecting to the transaction submission RPC at [%s]: %w", txSubmissionUrl, err)
 		}
+		txSubmissionClient = ethclient.NewClient(rpcClient)
 	}
 
 	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
+		endpoints:                  endpoints,
+		policy:                     policy,
+		logger:                     log.NewColorLogger(color.FgYellow),
+		consistencyCheck:           cfg.EcConsistencyCheck.Value == true,
+		consistencyMaxDepth:        cfg.EcConsistencyMaxDepth.Value.(uint64),
+		blockDivergentTransactions: cfg.EcConsistencyBlockTransactions.Value == true,
+		txSubmissionClient:         txSubmissionClient,
+		txSubmissionFallback:       cfg.StaderNode.TxSubmissionFallback.Value.(cfgtypes.TxSubmissionFallback),
 	}, nil
 
 }
@@ -185,7 +241,17 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 	if err != nil {
 		return 0, err
 	}
-	return result.(uint64), err
+	nonce := result.(uint64)
+
+	// Transactions sent privately aren't in the public mempool until they're mined, so the submission RPC may know of a higher nonce
+	if p.txSubmissionClient != nil {
+		submitCtx, cancel := context.WithTimeout(ctx, txSubmissionTimeout)
+		defer cancel()
+		if privateNonce, privateErr := p.txSubmissionClient.PendingNonceAt(submitCtx, account); privateErr == nil && privateNonce > nonce {
+			nonce = privateNonce
+		}
+	}
+	return nonce, nil
 }
 
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
@@ -212,6 +278,18 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the base fees and the given priority fee percentiles of the
+// blockCount blocks up to and including lastBlock. If lastBlock is nil, the latest block is used.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
@@ -229,8 +307,31 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if p.consistencyCheck && p.blockDivergentTransactions {
+		if err := p.getConsistencyError(ctx, p.getReadyEndpoints()); err != nil {
+			return fmt.Errorf("refusing to send the transaction while the Execution clients disagree: %w", err)
+		}
+	}
+
+	// Use the private submission RPC if there is one
+	if p.txSubmissionClient != nil {
+		err := p.sendPrivateTransaction(ctx, tx)
+		if err == nil {
+			return nil
+		}
+		if !p.shouldFallBackToPublic(ctx, err) {
+			return fmt.Errorf("the transaction submission RPC failed to send the transaction: %w", err)
+		}
+		p.logger.Printlnf("WARNING: transaction submission RPC failed (%s), sending the transaction through the Execution client instead...", err.Error())
+	}
+
+	// A timed out attempt may have reached the client, so a retry or the next client can find it already has the transaction
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
-		return nil, client.SendTransaction(ctx, tx)
+		err := client.SendTransaction(ctx, tx)
+		if err != nil && isAlreadyKnownError(err) {
+			return nil, nil
+		}
+		return nil, err
 	})
 	return err
 }
@@ -313,6 +414,12 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 		result := []interface{}{tx, isPending}
 		return result, err
 	})
+	if errors.Is(err, ethereum.NotFound) && p.txSubmissionClient != nil {
+		// Transactions sent privately aren't in the public mempool until they're mined, so ask the submission RPC about them
+		if tx, isPending, privateErr := p.txSubmissionClient.TransactionByHash(ctx, hash); privateErr == nil {
+			return tx, isPending, nil
+		}
+	}
 	if err != nil {
 		return nil, false, err
 	}
@@ -353,43 +460,77 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 
 func (p *ExecutionClientManager) CheckStatus(cfg *config.StaderConfig) *api.ClientManagerStatus {
 
-	status := &api.ClientManagerStatus{
-		FallbackEnabled: p.fallbackEc != nil,
-	}
+	p.statusLock.Lock()
 
 	// Ignore the sync check and just use the predefined settings if requested
-	if p.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = p.primaryReady
-		status.PrimaryClientStatus.IsSynced = p.primaryReady
-		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = p.fallbackReady
-			status.FallbackClientStatus.IsSynced = p.fallbackReady
-		}
-		return status
-	}
+	if !p.ignoreSyncCheck {
+		// Get the status and head block of each client
+		headBlocks := make([]uint64, len(p.endpoints))
+		bestHeadBlock := uint64(0)
+		expectedChainID := cfg.StaderNode.GetChainID()
+		for i, endpoint := range p.endpoints {
+			endpoint.status = checkEcStatus(endpoint.client, p.policy.HeadStallThreshold)
+
+			// Check if the fallbacks are using the expected network
+			if i > 0 && endpoint.status.IsWorking && endpoint.status.NetworkId != expectedChainID {
+				colorReset := "\033[0m"
+				colorYellow := "\033[33m"
+				endpoint.status.IsWorking = false
+				endpoint.status.IsSynced = false
+				endpoint.status.Error = fmt.Sprintf("The %s client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", strings.ToLower(endpoint.name), colorYellow, getNetworkNameFromId(endpoint.status.NetworkId), colorReset, endpoint.status.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
+				continue
+			}
 
-	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
+			if endpoint.status.IsStalled && endpoint.ready {
+				p.logger.Printlnf("WARNING: %s Execution client is stuck at block %d", endpoint.name, endpoint.status.HeadNumber)
+			}
+			if endpoint.status.IsSynced {
+				headBlocks[i] = endpoint.status.HeadNumber
+				if headBlocks[i] > bestHeadBlock {
+					bestHeadBlock = headBlocks[i]
+				}
+			}
+		}
 
-	// Flag if primary client is ready
-	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+		// Score them and flag the ready ones
+		scores := make([]float64, len(p.endpoints))
+		for i, endpoint := range p.endpoints {
+			headLag := uint64(0)
+			if headBlocks[i] < bestHeadBlock {
+				headLag = bestHeadBlock - headBlocks[i]
+			}
+			scores[i] = getHealthScore(endpoint.status, headLag, endpoint.guard.takeErrorRate())
+			endpoint.status.HealthScore = scores[i]
+			endpoint.ready = (endpoint.status.IsWorking && endpoint.status.IsSynced)
+		}
+		p.preferred = getPreferredEndpoint(scores)
+	} else {
+		for _, endpoint := range p.endpoints {
+			endpoint.status.IsWorking = endpoint.ready
+			endpoint.status.IsSynced = endpoint.ready
+		}
+	}
 
-	// Get the fallback EC status if applicable
+	status := &api.ClientManagerStatus{
+		PrimaryClientStatus:        p.endpoints[0].status,
+		FallbackEnabled:            len(p.endpoints) > 1,
+		AdditionalFallbackStatuses: []api.ClientStatus{},
+	}
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
-		// Check if fallback is using the expected network
-		expectedChainID := cfg.StaderNode.GetChainID()
-		if status.FallbackClientStatus.NetworkId != expectedChainID {
-			p.fallbackReady = false
-			colorReset := "\033[0m"
-			colorYellow := "\033[33m"
-			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", colorYellow, getNetworkNameFromId(status.FallbackClientStatus.NetworkId), colorReset, status.FallbackClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
-			return status
+		status.FallbackClientStatus = p.endpoints[1].status
+		for _, endpoint := range p.endpoints[2:] {
+			status.AdditionalFallbackStatuses = append(status.AdditionalFallbackStatuses, endpoint.status)
 		}
 	}
+	readyEndpoints := p.getReadyEndpointsLocked()
+	p.statusLock.Unlock()
 
-	p.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
+	// The consistency check queries the clients, so it runs without holding up requests waiting for the status lock
+	if p.consistencyCheck && !p.ignoreSyncCheck {
+		if err := p.getConsistencyError(context.Background(), readyEndpoints); err != nil {
+			status.ConsistencyError = err.Error()
+		}
+	}
 	return status
 }
 
@@ -406,7 +547,7 @@ func getNetworkNameFromId(networkId uint) string {
 }
 
 // Check the client status
-func checkEcStatus(client *ethclient.Client) api.ClientStatus {
+func checkEcStatus(client *ethclient.Client, headStallThreshold time.Duration) api.ClientStatus {
 
 	status := api.ClientStatus{}
 
@@ -435,18 +576,21 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 	// Make sure it's up to date
 	if progress == nil {
 
-		isUpToDate, blockTime, err := IsSyncWithinThreshold(client)
+		header, err := client.HeaderByNumber(context.Background(), nil)
 		if err != nil {
 			status.Error = fmt.Sprintf("Error checking if client's sync progress is up to date: [%s]", err.Error())
 			status.IsSynced = false
 			status.IsWorking = false
 			return status
 		}
+		status.HeadNumber = header.Number.Uint64()
+		status.HeadTime = time.Unix(int64(header.Time), 0)
 
 		status.IsWorking = true
-		if !isUpToDate {
-			status.Error = fmt.Sprintf("Client claims to have finished syncing, but its last block was from %s ago. It likely doesn't have enough peers", time.Since(blockTime))
+		if time.Since(status.HeadTime) > headStallThreshold {
+			status.Error = fmt.Sprintf("Client claims to have finished syncing, but its last block (%d) was from %s ago. It is likely stuck or doesn't have enough peers", status.HeadNumber, time.Since(status.HeadTime).Round(time.Second))
 			status.IsSynced = false
+			status.IsStalled = true
 			status.SyncProgress = 0
 			return status
 		}
@@ -474,68 +618,178 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
+// Get the ready clients in the order requests should try them: the preferred one first, then the rest in order of priority.
+// The caller must hold statusLock, since CheckStatus updates the ready flags.
+func (p *ExecutionClientManager) getEndpointOrder() []*ecEndpoint {
+	order := []*ecEndpoint{}
+	if p.preferred >= 0 && p.preferred < len(p.endpoints) && p.endpoints[p.preferred].ready {
+		order = append(order, p.endpoints[p.preferred])
+	}
+	for i, endpoint := range p.endpoints {
+		if i != p.preferred && endpoint.ready {
+			order = append(order, endpoint)
+		}
+	}
+	return order
+}
+
+// Check if the primary client is ready
+func (p *ExecutionClientManager) isPrimaryReady() bool {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	return p.endpoints[0].ready
+}
+
+// Check if any fallback client is ready
+func (p *ExecutionClientManager) isFallbackReady() bool {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	for _, endpoint := range p.endpoints[1:] {
+		if endpoint.ready {
+			return true
+		}
+	}
+	return false
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Transient errors are retried on the same client first, and clients that keep failing are skipped until their circuit breaker closes.
 func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
 
-	// Check if we can use the primary
-	if p.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
-				return p.runFunction(function)
-			}
+	p.statusLock.Lock()
+	order := p.getEndpointOrder()
+	p.statusLock.Unlock()
+	if len(order) == 0 {
+		return nil, fmt.Errorf("no Execution clients were ready")
+	}
 
-			// If it's a different error, just return it
-			return nil, err
+	// Skip clients while their circuit breaker is open, unless none of the others can be used either
+	available := []*ecEndpoint{}
+	for _, endpoint := range order {
+		if endpoint.guard.isAvailable() {
+			available = append(available, endpoint)
 		}
-
-		// If there's no error, return the result
-		return result, nil
+	}
+	if len(available) == 0 {
+		available = order[:1]
 	}
 
-	if p.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
-				p.fallbackReady = false
-				return nil, fmt.Errorf("all Execution clients failed")
+	for i, endpoint := range available {
+		var result interface{}
+		shouldFailover, err := endpoint.guard.run(func() error {
+			var err error
+			result, err = function(endpoint.client)
+			return err
+		})
+		if !shouldFailover {
+			// If it succeeded or the client returned a normal error, return the result
+			return result, err
+		}
+
+		// Log it and try the next client
+		isLast := (i == len(available)-1)
+		nextMessage := ", using the next client..."
+		if isLast {
+			nextMessage = ""
+		}
+		if p.isDisconnected(err) {
+			p.logger.Printlnf("WARNING: %s Execution client disconnected (%s)%s", endpoint.name, err.Error(), nextMessage)
+			p.statusLock.Lock()
+			endpoint.ready = false
+			p.statusLock.Unlock()
+		} else {
+			p.logger.Printlnf("WARNING: %s Execution client failed (%s)%s", endpoint.name, err.Error(), nextMessage)
+		}
+		if isLast {
+			if len(p.endpoints) == 1 {
+				return nil, err
 			}
+			return nil, fmt.Errorf("all Execution clients failed: %w", err)
+		}
+		endpoint.guard.recordFailover()
+	}
+
+	return nil, fmt.Errorf("no Execution clients were ready")
+}
 
-			// If it's a different error, just return it
-			return nil, err
+// Get the last known head of each client, in order of priority
+func (p *ExecutionClientManager) GetEndpointHeads() []ClientEndpointHead {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	heads := make([]ClientEndpointHead, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		heads[i] = ClientEndpointHead{
+			Endpoint:        endpoint.guard.getStats().Endpoint,
+			HeadNumber:      endpoint.status.HeadNumber,
+			HeadTime:        endpoint.status.HeadTime,
+			FinalizedEpoch:  endpoint.status.FinalizedEpoch,
+			IsStalled:       endpoint.status.IsStalled,
+			FinalityStalled: endpoint.status.FinalityStalled,
 		}
+	}
+	return heads
+}
 
-		// If there's no error, return the result
-		return result, nil
+// Get the request counters of each client, in order of priority
+func (p *ExecutionClientManager) GetEndpointStats() []ClientEndpointStats {
+	stats := make([]ClientEndpointStats, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		stats[i] = endpoint.guard.getStats()
 	}
+	return stats
+}
 
-	return nil, fmt.Errorf("no Execution clients were ready")
+// Send a transaction through the submission RPC
+func (p *ExecutionClientManager) sendPrivateTransaction(ctx context.Context, tx *types.Transaction) error {
+	submitCtx, cancel := context.WithTimeout(ctx, txSubmissionTimeout)
+	defer cancel()
+	return p.txSubmissionClient.SendTransaction(submitCtx, tx)
+}
+
+// Check if a transaction the submission RPC failed to send should go through the public Execution client instead
+func (p *ExecutionClientManager) shouldFallBackToPublic(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up, so there's no point trying anywhere else
+		return false
+	}
+	switch p.txSubmissionFallback {
+	case cfgtypes.TxSubmissionFallback_Always:
+		return true
+	case cfgtypes.TxSubmissionFallback_Unreachable:
+		// An RPC error means the transaction was rejected, so only fall back if there wasn't one
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return false
+		}
+		// A timed out request may still have reached the RPC, so sending the transaction publicly as well could leak it
+		if isTimeoutError(err) {
+			return false
+		}
+		return isDisconnectError(err) || isRetryableError(err)
+	default:
+		return false
+	}
 }
 
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return isDisconnectError(err)
+}
+
+// Returns true if the client rejected a transaction because it already has it in its pool
+func isAlreadyKnownError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "already known") || strings.Contains(message, "alreadyknown") || strings.Contains(message, "known transaction")
 }
 
 func (p *ExecutionClientManager) Version() (string, error) {
-	if !p.primaryReady && !p.fallbackReady {
+	p.statusLock.Lock()
+	order := p.getEndpointOrder()
+	p.statusLock.Unlock()
+	if len(order) == 0 {
 		return "", fmt.Errorf("EC not ready")
 	}
-
-	var url string
-
-	if p.primaryReady {
-		url = p.primaryEcUrl
-	} else {
-		url = p.fallbackEcUrl
-	}
+	url := order[0].url
 
 	payload := struct {
 		Jsonrpc string   `json:"jsonrpc"`
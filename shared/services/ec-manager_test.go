@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/fatih/color"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// A JSON-RPC error the mock server answers a method with
+type mockRpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// A JSON-RPC server that answers each method with a fixed result and records the methods it was called with
+type mockRpc struct {
+	server  *httptest.Server
+	results map[string]interface{}
+	status  int
+	lock    sync.Mutex
+	calls   []string
+}
+
+func newMockRpc(t *testing.T, results map[string]interface{}) *mockRpc {
+	m := &mockRpc{results: results}
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m.lock.Lock()
+		m.calls = append(m.calls, request.Method)
+		m.lock.Unlock()
+		if m.status != 0 {
+			http.Error(w, http.StatusText(m.status), m.status)
+			return
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch result := m.results[request.Method].(type) {
+		case nil:
+			response["error"] = mockRpcError{Code: -32601, Message: "method not found"}
+		case mockRpcError:
+			response["error"] = result
+		default:
+			response["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockRpc) called(method string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, call := range m.calls {
+		if call == method {
+			return true
+		}
+	}
+	return false
+}
+
+func dialTestClient(t *testing.T, url string) *ethclient.Client {
+	rpcClient, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(rpcClient.Close)
+	return ethclient.NewClient(rpcClient)
+}
+
+// Create a manager with a single public endpoint and a transaction submission RPC
+func newTestExecutionClientManager(t *testing.T, publicUrl string, submissionUrl string, fallback cfgtypes.TxSubmissionFallback) *ExecutionClientManager {
+	policy := &ClientPolicy{RetryBackoff: time.Millisecond}
+	client := dialTestClient(t, publicUrl)
+	return &ExecutionClientManager{
+		endpoints: []*ecEndpoint{{
+			name:   "Primary",
+			url:    publicUrl,
+			client: client,
+			guard:  newEndpointGuard("Primary", policy),
+			ready:  true,
+		}},
+		policy:               policy,
+		logger:               log.NewColorLogger(color.FgYellow),
+		txSubmissionClient:   dialTestClient(t, submissionUrl),
+		txSubmissionFallback: fallback,
+	}
+}
+
+func newTestTransaction(t *testing.T) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Gas:       21000,
+		GasFeeCap: big.NewInt(1e9),
+		GasTipCap: big.NewInt(1e9),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestSendTransactionFallback(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		submission     string
+		fallback       cfgtypes.TxSubmissionFallback
+		expectedPublic bool
+	}{
+		{"rejected, never", "rejected", cfgtypes.TxSubmissionFallback_Never, false},
+		{"rejected, unreachable", "rejected", cfgtypes.TxSubmissionFallback_Unreachable, false},
+		{"rejected, always", "rejected", cfgtypes.TxSubmissionFallback_Always, true},
+		{"server error, never", "server error", cfgtypes.TxSubmissionFallback_Never, false},
+		{"server error, unreachable", "server error", cfgtypes.TxSubmissionFallback_Unreachable, true},
+		{"offline, never", "offline", cfgtypes.TxSubmissionFallback_Never, false},
+		{"offline, unreachable", "offline", cfgtypes.TxSubmissionFallback_Unreachable, true},
+		{"offline, always", "offline", cfgtypes.TxSubmissionFallback_Always, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			public := newMockRpc(t, map[string]interface{}{"eth_sendRawTransaction": common.Hash{}.Hex()})
+			submission := newMockRpc(t, map[string]interface{}{"eth_sendRawTransaction": mockRpcError{Code: -32000, Message: "nonce too low"}})
+			switch test.submission {
+			case "server error":
+				submission.status = http.StatusServiceUnavailable
+			case "offline":
+				submission.server.Close()
+			}
+
+			manager := newTestExecutionClientManager(t, public.server.URL, submission.server.URL, test.fallback)
+			err := manager.SendTransaction(context.Background(), newTestTransaction(t))
+			if test.expectedPublic && err != nil {
+				t.Fatalf("expected the public client to send the transaction, got %v", err)
+			}
+			if !test.expectedPublic && err == nil {
+				t.Fatal("expected the submission RPC's error")
+			}
+			if public.called("eth_sendRawTransaction") != test.expectedPublic {
+				t.Fatalf("expected the public client to be used: %t", test.expectedPublic)
+			}
+		})
+	}
+
+}
+
+// A request that timed out may have reached the submission RPC, so it isn't sent publicly as well
+func TestShouldFallBackToPublicOnTimeout(t *testing.T) {
+	manager := &ExecutionClientManager{txSubmissionFallback: cfgtypes.TxSubmissionFallback_Unreachable}
+	timeout := fmt.Errorf("Post \"http://relay\": %w", context.DeadlineExceeded)
+	if manager.shouldFallBackToPublic(context.Background(), timeout) {
+		t.Fatal("expected a timed out transaction not to be sent publicly")
+	}
+
+	manager.txSubmissionFallback = cfgtypes.TxSubmissionFallback_Always
+	if !manager.shouldFallBackToPublic(context.Background(), timeout) {
+		t.Fatal("expected the always policy to send a timed out transaction publicly")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if manager.shouldFallBackToPublic(ctx, timeout) {
+		t.Fatal("expected nothing to be sent once the caller gave up")
+	}
+}
+
+func TestPendingNonceAtWithSubmissionRpc(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		submissionNonce interface{}
+		expected        uint64
+	}{
+		{"submission RPC is ahead", "0x7", 7},
+		{"public client is ahead", "0x3", 5},
+		{"submission RPC fails", mockRpcError{Code: -32601, Message: "method not found"}, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			public := newMockRpc(t, map[string]interface{}{"eth_getTransactionCount": "0x5"})
+			submission := newMockRpc(t, map[string]interface{}{"eth_getTransactionCount": test.submissionNonce})
+			manager := newTestExecutionClientManager(t, public.server.URL, submission.server.URL, cfgtypes.TxSubmissionFallback_Never)
+
+			nonce, err := manager.PendingNonceAt(context.Background(), common.Address{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nonce != test.expected {
+				t.Fatalf("got nonce %d, expected %d", nonce, test.expected)
+			}
+		})
+	}
+
+}
+
+func TestCheckEcStatus(t *testing.T) {
+
+	syncing := map[string]string{"startingBlock": "0x0", "currentBlock": "0x50", "highestBlock": "0x64"}
+	tests := []struct {
+		name             string
+		syncing          interface{}
+		headAge          time.Duration
+		expectedWorking  bool
+		expectedSynced   bool
+		expectedStalled  bool
+		expectedProgress float64
+	}{
+		{"synced", false, 30 * time.Second, true, true, false, 1},
+		{"head just inside the threshold", false, 59 * time.Second, true, true, false, 1},
+		{"stalled head", false, 2 * time.Minute, true, false, true, 0},
+		{"still syncing", syncing, time.Hour, true, false, false, 0.8},
+		{"sync check failed", mockRpcError{Code: -32000, Message: "unavailable"}, 0, false, false, false, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := &types.Header{
+				Number:     big.NewInt(100),
+				Time:       uint64(time.Now().Add(-test.headAge).Unix()),
+				Difficulty: big.NewInt(0),
+			}
+			rpc := newMockRpc(t, map[string]interface{}{
+				"net_version":          "1",
+				"eth_syncing":          test.syncing,
+				"eth_getBlockByNumber": header,
+			})
+
+			status := checkEcStatus(dialTestClient(t, rpc.server.URL), time.Minute)
+			if status.IsWorking != test.expectedWorking || status.IsSynced != test.expectedSynced || status.IsStalled != test.expectedStalled {
+				t.Fatalf("got working %t, synced %t and stalled %t, expected %t, %t and %t", status.IsWorking, status.IsSynced, status.IsStalled, test.expectedWorking, test.expectedSynced, test.expectedStalled)
+			}
+			if test.expectedStalled && (status.HeadNumber != 100 || status.Error == "") {
+				t.Fatalf("expected the stalled head to be reported, got %+v", status)
+			}
+			if status.SyncProgress != test.expectedProgress {
+				t.Fatalf("got sync progress %f, expected %f", status.SyncProgress, test.expectedProgress)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,117 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// The number of recent blocks to sample
+const blockCount uint64 = 20
+
+// The priority fee percentiles used for the slow, standard and fast suggestions
+var rewardPercentiles = []float64{10, 50, 90}
+
+// The number of blocks the base fee is allowed to rise for before each suggestion stops being includable.
+// The base fee can rise by at most 12.5% per block.
+const (
+	slowHeadroomBlocks     int = 1
+	standardHeadroomBlocks int = 3
+	fastHeadroomBlocks     int = 6
+
+	// Extra headroom when the base fee has been rising over the sampled blocks
+	risingHeadroomBlocks int = 2
+)
+
+// Get gas prices from the Execution client's recent fee history
+func GetGasPrices(client stader.ExecutionClient) (api.GasFeeSuggestion, error) {
+
+	// Get the fee history
+	history, err := client.FeeHistory(context.Background(), blockCount, nil, rewardPercentiles)
+	if err != nil {
+		return api.GasFeeSuggestion{}, fmt.Errorf("Error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return api.GasFeeSuggestion{}, fmt.Errorf("Execution client returned an empty fee history")
+	}
+
+	// The last base fee is the one of the next block; compare it to the oldest to get the trend
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	if nextBaseFee == nil {
+		return api.GasFeeSuggestion{}, fmt.Errorf("Execution client did not return a base fee; the network may not support EIP-1559")
+	}
+	rising := nextBaseFee.Cmp(history.BaseFee[0]) > 0
+
+	// Get the typical priority fee of each percentile, ignoring empty blocks which always report 0
+	tips := make([]*big.Int, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		tips[i] = getMedianReward(history, i)
+	}
+
+	// Networks with mostly empty blocks don't have a tip history, so use the client's own suggestion
+	if tips[len(tips)-1].Sign() == 0 {
+		tip, err := client.SuggestGasTipCap(context.Background())
+		if err != nil {
+			return api.GasFeeSuggestion{}, fmt.Errorf("Error getting suggested priority fee: %w", err)
+		}
+		for i := range tips {
+			tips[i] = tip
+		}
+	}
+
+	extraHeadroom := 0
+	if rising {
+		extraHeadroom = risingHeadroomBlocks
+	}
+	return api.GasFeeSuggestion{
+		BaseFeeWei:    nextBaseFee,
+		BaseFeeRising: rising,
+		Slow:          getGasFee(nextBaseFee, slowHeadroomBlocks+extraHeadroom, tips[0]),
+		Standard:      getGasFee(nextBaseFee, standardHeadroomBlocks+extraHeadroom, tips[1]),
+		Fast:          getGasFee(nextBaseFee, fastHeadroomBlocks+extraHeadroom, tips[2]),
+	}, nil
+
+}
+
+// Get a max fee that covers the base fee rising for the given number of full blocks, plus the priority fee
+func getGasFee(baseFee *big.Int, headroomBlocks int, priorityFee *big.Int) api.GasFee {
+	maxBaseFee := new(big.Int).Set(baseFee)
+	for i := 0; i < headroomBlocks; i++ {
+		maxBaseFee.Mul(maxBaseFee, big.NewInt(9))
+		maxBaseFee.Div(maxBaseFee, big.NewInt(8))
+	}
+	return api.GasFee{
+		MaxFeeWei:      maxBaseFee.Add(maxBaseFee, priorityFee),
+		PriorityFeeWei: new(big.Int).Set(priorityFee),
+	}
+}
+
+// Get the median reward of the given percentile across the sampled blocks that had transactions
+func getMedianReward(history *ethereum.FeeHistory, percentile int) *big.Int {
+	rewards := []*big.Int{}
+	for i, blockRewards := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if percentile < len(blockRewards) && blockRewards[percentile] != nil {
+			rewards = append(rewards, blockRewards[percentile])
+		}
+	}
+	return median(rewards)
+}
+
+// Sort a list of values and get the middle one
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	return values[len(values)/2]
+}
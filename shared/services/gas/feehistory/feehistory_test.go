@@ -0,0 +1,105 @@
+package feehistory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+func TestGetGasFee(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		baseFee        int64
+		headroomBlocks int
+		priorityFee    int64
+		expectedMaxFee int64
+	}{
+		{"no headroom", 800, 0, 2, 802},
+		{"one full block", 800, 1, 2, 902},
+		{"rounded down each block", 800, 2, 2, 1014},
+		{"no priority fee", 800, 1, 0, 900},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			baseFee := big.NewInt(test.baseFee)
+			priorityFee := big.NewInt(test.priorityFee)
+			fee := getGasFee(baseFee, test.headroomBlocks, priorityFee)
+			if fee.MaxFeeWei.Cmp(big.NewInt(test.expectedMaxFee)) != 0 {
+				t.Fatalf("got max fee %s, expected %d", fee.MaxFeeWei, test.expectedMaxFee)
+			}
+			if fee.PriorityFeeWei.Cmp(priorityFee) != 0 {
+				t.Fatalf("got priority fee %s, expected %s", fee.PriorityFeeWei, priorityFee)
+			}
+
+			// The inputs are left alone
+			fee.PriorityFeeWei.SetInt64(99)
+			if baseFee.Int64() != test.baseFee || priorityFee.Int64() != test.priorityFee {
+				t.Fatal("getGasFee modified its inputs")
+			}
+		})
+	}
+
+}
+
+func TestMedian(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		values   []int64
+		expected int64
+	}{
+		{"empty", []int64{}, 0},
+		{"one value", []int64{7}, 7},
+		{"odd count", []int64{9, 1, 5}, 5},
+		{"even count takes the upper middle", []int64{4, 1, 3, 2}, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := []*big.Int{}
+			for _, value := range test.values {
+				values = append(values, big.NewInt(value))
+			}
+			if result := median(values); result.Cmp(big.NewInt(test.expected)) != 0 {
+				t.Fatalf("got %s, expected %d", result, test.expected)
+			}
+		})
+	}
+
+}
+
+func TestGetMedianReward(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		Reward: [][]*big.Int{
+			{big.NewInt(1), big.NewInt(10), big.NewInt(100)},
+			// An empty block reports 0 for every percentile
+			{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+			{big.NewInt(3), big.NewInt(30), big.NewInt(300)},
+			{big.NewInt(2), big.NewInt(20)},
+			{big.NewInt(5), nil, big.NewInt(500)},
+		},
+		GasUsedRatio: []float64{0.5, 0, 0.9, 0.3, 0.7},
+	}
+
+	tests := []struct {
+		name       string
+		percentile int
+		expected   int64
+	}{
+		{"slow", 0, 3},
+		{"standard skips missing rewards", 1, 20},
+		{"fast skips blocks without it", 2, 300},
+		{"unknown percentile", 3, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := getMedianReward(history, test.percentile); result.Cmp(big.NewInt(test.expected)) != 0 {
+				t.Fatalf("got %s, expected %d", result, test.expected)
+			}
+		})
+	}
+}
@@ -26,9 +26,12 @@ import (
 
 	"github.com/stader-labs/stader-node/shared/utils/log"
 
+	"github.com/stader-labs/stader-node/shared/services/config"
 	"github.com/stader-labs/stader-node/shared/services/gas/etherchain"
 	"github.com/stader-labs/stader-node/shared/services/gas/etherscan"
 	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 	"github.com/stader-labs/stader-node/shared/utils/math"
 	staderCore "github.com/stader-labs/stader-node/stader-lib/stader"
@@ -37,6 +40,11 @@ import (
 
 func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Client, headless bool) error {
 
+	// Don't offer to send a transaction that the simulation showed would revert
+	if gasInfo.SimulationError != "" {
+		return fmt.Errorf("This transaction would fail if it were sent now: %s", gasInfo.SimulationError)
+	}
+
 	cfg, isNew, err := staderClient.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("Error getting Stader configuration: %w", err)
@@ -84,27 +92,39 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 
 	} else {
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
+			maxFeeWei, err := GetHeadlessMaxFeeWei(staderClient, cfg)
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
+			// Try to get the latest gas prices from the Execution client
+			gasPrices, err := staderClient.GetGasPrices()
 			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, maxPriorityFeeGwei)
-
+				// Print the suggestions and ask for an amount
+				maxFeeGwei = handleFeeHistoryGasPrices(gasPrices.Suggestion, maxPriorityFeeGwei)
 			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", log.ColorYellow, err.Error(), log.ColorReset)
-				etherscanData, err := etherscan.GetGasPrices()
+				if cfg.StaderNode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Mainnet {
+					return fmt.Errorf("Error getting gas price suggestions: %w", err)
+				}
+
+				// Fallback to Etherchain and Etherscan, which only support mainnet
+				fmt.Printf("%sWarning: couldn't get gas estimates from your Execution client - %s\nFalling back to Etherchain%s\n", log.ColorYellow, err.Error(), log.ColorReset)
+				etherchainData, err := etherchain.GetGasPrices()
 				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, maxPriorityFeeGwei)
+					// Print the Etherchain data and ask for an amount
+					maxFeeGwei = handleEtherchainGasPrices(etherchainData, maxPriorityFeeGwei)
+
 				} else {
-					return fmt.Errorf("Error getting gas price suggestions: %w", err)
+					// Fallback to Etherscan
+					fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", log.ColorYellow, err.Error(), log.ColorReset)
+					etherscanData, err := etherscan.GetGasPrices()
+					if err == nil {
+						// Print the Etherscan data and ask for an amount
+						maxFeeGwei = handleEtherscanGasPrices(etherscanData, maxPriorityFeeGwei)
+					} else {
+						return fmt.Errorf("Error getting gas price suggestions: %w", err)
+					}
 				}
 			}
 		}
@@ -125,7 +145,17 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 }
 
 // Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
+func GetHeadlessMaxFeeWei(staderClient *stader.Client, cfg *config.StaderConfig) (*big.Int, error) {
+	gasPrices, err := staderClient.GetGasPrices()
+	if err == nil {
+		return gasPrices.Suggestion.Fast.MaxFeeWei, nil
+	}
+	if cfg.StaderNode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Mainnet {
+		return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
+	}
+
+	// Fallback to Etherchain and Etherscan, which only support mainnet
+	fmt.Printf("%sWarning: couldn't get gas estimates from your Execution client - %s\nFalling back to Etherchain%s\n", log.ColorYellow, err.Error(), log.ColorReset)
 	etherchainData, err := etherchain.GetGasPrices()
 	if err == nil {
 		return etherchainData.RapidWei, nil
@@ -140,6 +170,50 @@ func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
 }
 
+func handleFeeHistoryGasPrices(gasSuggestion api.GasFeeSuggestion, priorityFee float64) float64 {
+
+	trend := "falling"
+	if gasSuggestion.BaseFeeRising {
+		trend = "rising"
+	}
+	fmt.Printf("%sThe current base fee is %.2f gwei and has been %s.\n", log.ColorBlue, eth.WeiToGwei(gasSuggestion.BaseFeeWei), trend)
+	fmt.Println("Suggested max fees based on recent blocks:")
+	fmt.Printf("\tSlow:     %.2f gwei (priority fee %.2f gwei)\n", eth.WeiToGwei(gasSuggestion.Slow.MaxFeeWei), eth.WeiToGwei(gasSuggestion.Slow.PriorityFeeWei))
+	fmt.Printf("\tStandard: %.2f gwei (priority fee %.2f gwei)\n", eth.WeiToGwei(gasSuggestion.Standard.MaxFeeWei), eth.WeiToGwei(gasSuggestion.Standard.PriorityFeeWei))
+	fmt.Printf("\tFast:     %.2f gwei (priority fee %.2f gwei)%s\n", eth.WeiToGwei(gasSuggestion.Fast.MaxFeeWei), eth.WeiToGwei(gasSuggestion.Fast.PriorityFeeWei), log.ColorReset)
+	if priorityFee < eth.WeiToGwei(gasSuggestion.Slow.PriorityFeeWei) {
+		fmt.Printf("%sNOTE: your priority fee of %.2f gwei is lower than most recent transactions paid, so your transaction may take a while to be included.%s\n", log.ColorYellow, priorityFee, log.ColorReset)
+	}
+
+	// Leave room for the base fee to rise as much as in the fast suggestion, on top of the requested priority fee
+	fastBaseFeeGwei := eth.WeiToGwei(new(big.Int).Sub(gasSuggestion.Fast.MaxFeeWei, gasSuggestion.Fast.PriorityFeeWei))
+	fastGwei := math.RoundUp(fastBaseFeeGwei+priorityFee, 0)
+
+	for {
+		desiredPrice := cliutils.Prompt(
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
+			"Not a valid gas price, try again:")
+
+		if desiredPrice == "" {
+			return fastGwei
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		return desiredPriceFloat
+	}
+
+}
+
 func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, priorityFee float64) float64 {
 	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.FastWei)+priorityFee, 0)
 
@@ -0,0 +1,430 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+)
+
+// Config
+const RequestTimeout = 30 * time.Second
+
+// Statuses the keymanager API reports for each key in an import or delete
+const (
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+	StatusDeleted   = "deleted"
+	StatusNotActive = "not_active"
+	StatusNotFound  = "not_found"
+	StatusError     = "error"
+)
+
+// Returned when the validator client can't be reached through its keymanager API, so callers can fall back to restarting it
+var ErrUnavailable = errors.New("The validator client's keymanager API is unavailable")
+
+// Client for the standard Ethereum keymanager API of a validator client
+type Client struct {
+	url       string
+	tokenPath string
+	client    *http.Client
+}
+
+// A key the validator client holds in a local keystore
+type Keystore struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	Readonly         bool   `json:"readonly,omitempty"`
+}
+
+// A key the validator client signs with through a remote signer
+type RemoteKey struct {
+	Pubkey   string `json:"pubkey"`
+	Url      string `json:"url,omitempty"`
+	Readonly bool   `json:"readonly,omitempty"`
+}
+
+// The result of importing or deleting one key
+type Status struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Encrypted validator key store, as imported through the API
+type validatorKey struct {
+	Crypto  map[string]interface{}      `json:"crypto"`
+	Version uint                        `json:"version"`
+	UUID    uuid.UUID                   `json:"uuid"`
+	Path    string                      `json:"path"`
+	Pubkey  stadertypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Create a new keymanager API client; the bearer token is read from the token file on every request.
+// An empty token path sends no token, for APIs such as Web3Signer's that don't use one.
+func NewClient(url string, tokenPath string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		tokenPath: tokenPath,
+		client:    &http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// Encrypt a validator key into an EIP-2335 keystore for importing, returning it with its password
+func EncryptKeystore(key *eth2types.BLSPrivateKey, derivationPath string) (string, string, error) {
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return "", "", fmt.Errorf("Could not generate random password: %w", err)
+	}
+	encryptor := eth2ks.New(eth2ks.WithCipher("scrypt"))
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encode validator key: %w", err)
+	}
+	return string(keyStoreBytes), password, nil
+}
+
+// List the keys loaded from local keystores
+func (c *Client) ListKeystores() ([]Keystore, error) {
+	var response struct {
+		Data []Keystore `json:"data"`
+	}
+	if err := c.request(http.MethodGet, "/eth/v1/keystores", nil, &response); err != nil {
+		return nil, fmt.Errorf("Could not list keystores: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Import EIP-2335 keystores, optionally with their EIP-3076 slashing protection history
+func (c *Client) ImportKeystores(keystores []string, passwords []string, slashingProtection string) ([]Status, error) {
+	request := struct {
+		Keystores          []string `json:"keystores"`
+		Passwords          []string `json:"passwords"`
+		SlashingProtection string   `json:"slashing_protection,omitempty"`
+	}{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	}
+	var response struct {
+		Data []Status `json:"data"`
+	}
+	if err := c.request(http.MethodPost, "/eth/v1/keystores", request, &response); err != nil {
+		return nil, fmt.Errorf("Could not import keystores: %w", err)
+	}
+	if len(response.Data) != len(keystores) {
+		return nil, fmt.Errorf("Validator client returned %d import results for %d keystores", len(response.Data), len(keystores))
+	}
+	return response.Data, nil
+}
+
+// Delete keys loaded from local keystores, returning their EIP-3076 slashing protection history
+func (c *Client) DeleteKeystores(pubkeys []stadertypes.ValidatorPubkey) ([]Status, string, error) {
+	request := struct {
+		Pubkeys []string `json:"pubkeys"`
+	}{
+		Pubkeys: prefixedPubkeys(pubkeys),
+	}
+	var response struct {
+		Data               []Status `json:"data"`
+		SlashingProtection string   `json:"slashing_protection"`
+	}
+	if err := c.request(http.MethodDelete, "/eth/v1/keystores", request, &response); err != nil {
+		return nil, "", fmt.Errorf("Could not delete keystores: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, "", fmt.Errorf("Validator client returned %d delete results for %d keys", len(response.Data), len(pubkeys))
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// List the keys signed for by a remote signer
+func (c *Client) ListRemoteKeys() ([]RemoteKey, error) {
+	var response struct {
+		Data []RemoteKey `json:"data"`
+	}
+	if err := c.request(http.MethodGet, "/eth/v1/remotekeys", nil, &response); err != nil {
+		return nil, fmt.Errorf("Could not list remote keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Import keys signed for by a remote signer
+func (c *Client) ImportRemoteKeys(remoteKeys []RemoteKey) ([]Status, error) {
+	request := struct {
+		RemoteKeys []RemoteKey `json:"remote_keys"`
+	}{
+		RemoteKeys: remoteKeys,
+	}
+	var response struct {
+		Data []Status `json:"data"`
+	}
+	if err := c.request(http.MethodPost, "/eth/v1/remotekeys", request, &response); err != nil {
+		return nil, fmt.Errorf("Could not import remote keys: %w", err)
+	}
+	if len(response.Data) != len(remoteKeys) {
+		return nil, fmt.Errorf("Validator client returned %d import results for %d remote keys", len(response.Data), len(remoteKeys))
+	}
+	return response.Data, nil
+}
+
+// Delete keys signed for by a remote signer
+func (c *Client) DeleteRemoteKeys(pubkeys []stadertypes.ValidatorPubkey) ([]Status, error) {
+	request := struct {
+		Pubkeys []string `json:"pubkeys"`
+	}{
+		Pubkeys: prefixedPubkeys(pubkeys),
+	}
+	var response struct {
+		Data []Status `json:"data"`
+	}
+	if err := c.request(http.MethodDelete, "/eth/v1/remotekeys", request, &response); err != nil {
+		return nil, fmt.Errorf("Could not delete remote keys: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, fmt.Errorf("Validator client returned %d delete results for %d remote keys", len(response.Data), len(pubkeys))
+	}
+	return response.Data, nil
+}
+
+// List the pubkeys of every key the validator client has loaded, local or remote
+func (c *Client) ListPubkeys() ([]stadertypes.ValidatorPubkey, error) {
+	keystores, err := c.ListKeystores()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := make([]stadertypes.ValidatorPubkey, 0, len(keystores))
+	for _, keystore := range keystores {
+		pubkey, err := stadertypes.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.ValidatingPubkey))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	// Not every client supports remote keys
+	remoteKeys, err := c.ListRemoteKeys()
+	if errors.Is(err, ErrUnavailable) {
+		return pubkeys, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, remoteKey := range remoteKeys {
+		pubkey, err := stadertypes.HexToValidatorPubkey(hexutil.RemovePrefix(remoteKey.Pubkey))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Get the fee recipient the validator client uses for a validator
+func (c *Client) GetFeeRecipient(pubkey stadertypes.ValidatorPubkey) (common.Address, error) {
+	var response struct {
+		Data struct {
+			Pubkey     string         `json:"pubkey"`
+			Ethaddress common.Address `json:"ethaddress"`
+		} `json:"data"`
+	}
+	if err := c.request(http.MethodGet, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/feerecipient", nil, &response); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get the fee recipient of validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.Ethaddress, nil
+}
+
+// Set the fee recipient the validator client uses for a validator, overriding its default
+func (c *Client) SetFeeRecipient(pubkey stadertypes.ValidatorPubkey, feeRecipient common.Address) error {
+	request := struct {
+		Ethaddress string `json:"ethaddress"`
+	}{
+		Ethaddress: feeRecipient.Hex(),
+	}
+	if err := c.request(http.MethodPost, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/feerecipient", request, nil); err != nil {
+		return fmt.Errorf("Could not set the fee recipient of validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the graffiti the validator client uses for a validator
+func (c *Client) GetGraffiti(pubkey stadertypes.ValidatorPubkey) (string, error) {
+	var response struct {
+		Data struct {
+			Pubkey   string `json:"pubkey"`
+			Graffiti string `json:"graffiti"`
+		} `json:"data"`
+	}
+	if err := c.request(http.MethodGet, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/graffiti", nil, &response); err != nil {
+		return "", fmt.Errorf("Could not get the graffiti of validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.Graffiti, nil
+}
+
+// Set the graffiti the validator client uses for a validator, overriding its default
+func (c *Client) SetGraffiti(pubkey stadertypes.ValidatorPubkey, graffiti string) error {
+	request := struct {
+		Graffiti string `json:"graffiti"`
+	}{
+		Graffiti: graffiti,
+	}
+	if err := c.request(http.MethodPost, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/graffiti", request, nil); err != nil {
+		return fmt.Errorf("Could not set the graffiti of validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Remove the graffiti override of a validator so the validator client's default applies again
+func (c *Client) DeleteGraffiti(pubkey stadertypes.ValidatorPubkey) error {
+	if err := c.request(http.MethodDelete, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/graffiti", nil, nil); err != nil {
+		return fmt.Errorf("Could not delete the graffiti of validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the gas limit the validator client registers with builders for a validator
+func (c *Client) GetGasLimit(pubkey stadertypes.ValidatorPubkey) (uint64, error) {
+	var response struct {
+		Data struct {
+			Pubkey   string `json:"pubkey"`
+			GasLimit string `json:"gas_limit"`
+		} `json:"data"`
+	}
+	if err := c.request(http.MethodGet, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/gas_limit", nil, &response); err != nil {
+		return 0, fmt.Errorf("Could not get the gas limit of validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not decode the gas limit of validator %s: %w", pubkey.Hex(), err)
+	}
+	return gasLimit, nil
+}
+
+// Set the gas limit the validator client registers with builders for a validator, overriding its default
+func (c *Client) SetGasLimit(pubkey stadertypes.ValidatorPubkey, gasLimit uint64) error {
+	request := struct {
+		GasLimit string `json:"gas_limit"`
+	}{
+		GasLimit: strconv.FormatUint(gasLimit, 10),
+	}
+	if err := c.request(http.MethodPost, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/gas_limit", request, nil); err != nil {
+		return fmt.Errorf("Could not set the gas limit of validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Remove the gas limit override of a validator so the validator client's default applies again
+func (c *Client) DeleteGasLimit(pubkey stadertypes.ValidatorPubkey) error {
+	if err := c.request(http.MethodDelete, "/eth/v1/validator/"+hexutil.AddPrefix(pubkey.Hex())+"/gas_limit", nil, nil); err != nil {
+		return fmt.Errorf("Could not delete the gas limit of validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Ask a Web3Signer to sign a message with one of its keys, returning the hex-encoded signature.
+// This isn't part of the keymanager API, but Web3Signer serves it alongside it.
+func (c *Client) Sign(pubkey stadertypes.ValidatorPubkey, request interface{}) (string, error) {
+	var response struct {
+		Signature string `json:"signature"`
+	}
+	if err := c.request(http.MethodPost, "/api/v1/eth2/sign/"+hexutil.AddPrefix(pubkey.Hex()), request, &response); err != nil {
+		return "", fmt.Errorf("Could not sign with validator key %s: %w", pubkey.Hex(), err)
+	}
+	return response.Signature, nil
+}
+
+// Send an authenticated request to the keymanager API and decode the response.
+// Failing to reach the API at all, or an endpoint the client doesn't serve, is reported as ErrUnavailable.
+func (c *Client) request(method string, path string, request interface{}, response interface{}) error {
+
+	// Get the bearer token
+	var token []byte
+	if c.tokenPath != "" {
+		var err error
+		token, err = ioutil.ReadFile(c.tokenPath)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: token file %s doesn't exist", ErrUnavailable, c.tokenPath)
+		} else if err != nil {
+			return fmt.Errorf("Could not read keymanager API token: %w", err)
+		}
+	}
+
+	// Build the request
+	var body *bytes.Reader
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("Could not encode request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+	httpRequest, err := http.NewRequest(method, c.url+path, body)
+	if err != nil {
+		return err
+	}
+	if token != nil {
+		httpRequest.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	// Send it
+	httpResponse, err := c.client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("Could not read response: %w", err)
+	}
+	switch httpResponse.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	case http.StatusNotFound, http.StatusNotImplemented:
+		return fmt.Errorf("%w: %s %s returned %s", ErrUnavailable, method, path, httpResponse.Status)
+	default:
+		return fmt.Errorf("%s %s failed with status %s: %s", method, path, httpResponse.Status, strings.TrimSpace(string(responseBytes)))
+	}
+	if response == nil || len(responseBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		return fmt.Errorf("Could not decode response: %w", err)
+	}
+	return nil
+
+}
+
+// Get the 0x-prefixed hex encoding of validator pubkeys, as the API expects
+func prefixedPubkeys(pubkeys []stadertypes.ValidatorPubkey) []string {
+	prefixed := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		prefixed[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	return prefixed
+}
@@ -0,0 +1,237 @@
+package keymanager
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+const testToken = "api-token"
+
+// A keymanager API that checks the bearer token and answers every request with a fixed status and body
+type mockKeymanager struct {
+	server   *httptest.Server
+	status   int
+	response interface{}
+	request  map[string]interface{}
+	path     string
+}
+
+func newMockKeymanager(t *testing.T) *mockKeymanager {
+	m := &mockKeymanager{status: http.StatusOK}
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, `{"message":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		m.path = r.Method + " " + r.URL.Path
+		m.request = nil
+		json.NewDecoder(r.Body).Decode(&m.request)
+		w.WriteHeader(m.status)
+		if m.response != nil {
+			json.NewEncoder(w).Encode(m.response)
+		}
+	}))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// Create a client for the mock, with the token file written the way validator clients write it
+func newTestClient(t *testing.T, m *mockKeymanager) *Client {
+	tokenPath := filepath.Join(t.TempDir(), "api-token.txt")
+	if err := ioutil.WriteFile(tokenPath, []byte(testToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(m.server.URL+"/", tokenPath)
+}
+
+func testPubkeys(count int) []stadertypes.ValidatorPubkey {
+	pubkeys := make([]stadertypes.ValidatorPubkey, count)
+	for i := range pubkeys {
+		pubkeys[i][0] = byte(i + 1)
+	}
+	return pubkeys
+}
+
+func TestBearerToken(t *testing.T) {
+	m := newMockKeymanager(t)
+	m.response = map[string]interface{}{"data": []Keystore{}}
+
+	// The token is read from the file and trimmed
+	if _, err := newTestClient(t, m).ListKeystores(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A wrong token is an error, but the API is there
+	wrongTokenPath := filepath.Join(t.TempDir(), "api-token.txt")
+	if err := ioutil.WriteFile(wrongTokenPath, []byte("wrong"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewClient(m.server.URL, wrongTokenPath).ListKeystores()
+	if err == nil || errors.Is(err, ErrUnavailable) || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+
+	// A missing token file means the validator client hasn't set up its API
+	_, err = NewClient(m.server.URL, filepath.Join(t.TempDir(), "missing.txt")).ListKeystores()
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable without a token file, got %v", err)
+	}
+
+	// No token path sends no token
+	_, err = NewClient(m.server.URL, "").ListKeystores()
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected the request without a token to be rejected, got %v", err)
+	}
+}
+
+func TestRequestStatus(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		status              int
+		offline             bool
+		expectedError       bool
+		expectedUnavailable bool
+	}{
+		{"ok", http.StatusOK, false, false, false},
+		{"accepted", http.StatusAccepted, false, false, false},
+		{"route missing", http.StatusNotFound, false, true, true},
+		{"not implemented", http.StatusNotImplemented, false, true, true},
+		{"server error", http.StatusInternalServerError, false, true, false},
+		{"bad request", http.StatusBadRequest, false, true, false},
+		{"offline", http.StatusOK, true, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newMockKeymanager(t)
+			m.status = test.status
+			m.response = map[string]interface{}{"message": "something went wrong"}
+			client := newTestClient(t, m)
+			if test.offline {
+				m.server.Close()
+			}
+
+			err := client.SetFeeRecipient(testPubkeys(1)[0], [20]byte{1})
+			if (err != nil) != test.expectedError {
+				t.Fatalf("got %v, expected an error: %t", err, test.expectedError)
+			}
+			if errors.Is(err, ErrUnavailable) != test.expectedUnavailable {
+				t.Fatalf("got %v, expected ErrUnavailable: %t", err, test.expectedUnavailable)
+			}
+			if test.status == http.StatusInternalServerError && !strings.Contains(err.Error(), "something went wrong") {
+				t.Fatalf("expected the response body in the error, got %v", err)
+			}
+		})
+	}
+
+}
+
+func TestImportKeystores(t *testing.T) {
+	m := newMockKeymanager(t)
+	m.response = map[string]interface{}{"data": []Status{
+		{Status: StatusImported},
+		{Status: StatusDuplicate},
+		{Status: StatusError, Message: "invalid password"},
+	}}
+
+	statuses, err := newTestClient(t, m).ImportKeystores([]string{"{}", "{}", "{}"}, []string{"a", "b", "c"}, `{"data":[]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.path != "POST /eth/v1/keystores" || m.request["slashing_protection"] != `{"data":[]}` || len(m.request["passwords"].([]interface{})) != 3 {
+		t.Fatalf("unexpected request %s %v", m.path, m.request)
+	}
+	if len(statuses) != 3 || statuses[0].Status != StatusImported || statuses[1].Status != StatusDuplicate || statuses[2].Message != "invalid password" {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+}
+
+func TestDeleteKeystores(t *testing.T) {
+	m := newMockKeymanager(t)
+	m.response = map[string]interface{}{
+		"data":                []Status{{Status: StatusDeleted}, {Status: StatusNotActive}, {Status: StatusNotFound}},
+		"slashing_protection": `{"data":[{"pubkey":"0x01"}]}`,
+	}
+
+	pubkeys := testPubkeys(3)
+	statuses, slashingProtection, err := newTestClient(t, m).DeleteKeystores(pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.path != "DELETE /eth/v1/keystores" || m.request["pubkeys"].([]interface{})[0] != "0x"+pubkeys[0].Hex() {
+		t.Fatalf("unexpected request %s %v", m.path, m.request)
+	}
+	if len(statuses) != 3 || statuses[0].Status != StatusDeleted || statuses[1].Status != StatusNotActive || statuses[2].Status != StatusNotFound {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	if slashingProtection != `{"data":[{"pubkey":"0x01"}]}` {
+		t.Fatalf("unexpected slashing protection %s", slashingProtection)
+	}
+}
+
+// A client that answers for a different number of keys than it was asked about can't be matched up, so it's an error
+func TestResultCountMismatch(t *testing.T) {
+	m := newMockKeymanager(t)
+	m.response = map[string]interface{}{"data": []Status{{Status: StatusImported}}}
+	client := newTestClient(t, m)
+	pubkeys := testPubkeys(2)
+
+	calls := map[string]func() error{
+		"import keystores": func() error {
+			_, err := client.ImportKeystores([]string{"{}", "{}"}, []string{"a", "b"}, "")
+			return err
+		},
+		"delete keystores": func() error {
+			_, _, err := client.DeleteKeystores(pubkeys)
+			return err
+		},
+		"import remote keys": func() error {
+			_, err := client.ImportRemoteKeys([]RemoteKey{{Pubkey: "0x01"}, {Pubkey: "0x02"}})
+			return err
+		},
+		"delete remote keys": func() error {
+			_, err := client.DeleteRemoteKeys(pubkeys)
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); err == nil || !strings.Contains(err.Error(), "returned 1") {
+				t.Fatalf("expected a result count error, got %v", err)
+			}
+		})
+	}
+}
+
+// Clients without the remote keys API still list their local keys
+func TestListPubkeysWithoutRemoteKeys(t *testing.T) {
+	pubkeys := testPubkeys(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/keystores" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []Keystore{
+			{ValidatingPubkey: "0x" + pubkeys[0].Hex()},
+			{ValidatingPubkey: pubkeys[1].Hex()},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	listed, err := NewClient(server.URL, "").ListPubkeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || listed[0] != pubkeys[0] || listed[1] != pubkeys[1] {
+		t.Fatalf("got %v, expected %v", listed, pubkeys)
+	}
+}
@@ -76,6 +76,18 @@ func RequireNodeWallet(c *cli.Context) error {
 	return nil
 }
 
+// Require the node account, which can be a watch-only address on a machine without the node wallet
+func RequireNodeAccount(c *cli.Context) error {
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if w.IsWatchOnly() {
+		return nil
+	}
+	return RequireNodeWallet(c)
+}
+
 func RequireEthClientSynced(c *cli.Context) error {
 	ethClientSynced, err := waitEthClientSynced(c, false, EthClientSyncTimeout)
 	if err != nil {
@@ -99,7 +111,7 @@ func RequireBeaconClientSynced(c *cli.Context) error {
 }
 
 func RequireNodeRegistered(c *cli.Context) error {
-	if err := RequireNodeWallet(c); err != nil {
+	if err := RequireNodeAccount(c); err != nil {
 		return err
 	}
 	nodeRegistered, err := isNodeRegistered(c)
@@ -113,7 +125,7 @@ func RequireNodeRegistered(c *cli.Context) error {
 }
 
 func RequireNodeActive(c *cli.Context) error {
-	if err := RequireNodeWallet(c); err != nil {
+	if err := RequireNodeAccount(c); err != nil {
 		return err
 	}
 	nodeActive, err := isNodeActive(c)
@@ -275,12 +287,12 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Stade
 
 	// Check the EC status
 	mgrStatus := ecMgr.CheckStatus(cfg)
-	if ecMgr.primaryReady {
+	if ecMgr.isPrimaryReady() {
 		return true, nil, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if ecMgr.fallbackReady {
+	if ecMgr.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary execution client is unavailable (%s), using fallback execution client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -294,13 +306,13 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Stade
 	// Is the primary working and syncing? If so, wait for it
 	if mgrStatus.PrimaryClientStatus.IsWorking && mgrStatus.PrimaryClientStatus.Error == "" {
 		log.Printf("Fallback execution client is not configured or unavailable, waiting for primary execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
-		return false, ecMgr.primaryEc, nil
+		return false, ecMgr.endpoints[0].client, nil
 	}
 
 	// Is the fallback working and syncing? If so, wait for it
 	if mgrStatus.FallbackEnabled && mgrStatus.FallbackClientStatus.IsWorking && mgrStatus.FallbackClientStatus.Error == "" {
 		log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.SyncProgress*100)
-		return false, ecMgr.fallbackEc, nil
+		return false, ecMgr.endpoints[1].client, nil
 	}
 
 	// If neither client is working, report the errors
@@ -315,12 +327,12 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
 	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
+	if bcMgr.isPrimaryReady() {
 		return true, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
+	if bcMgr.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
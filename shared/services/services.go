@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -42,6 +43,7 @@ import (
 	nmkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/web3signer"
 	staderUtils "github.com/stader-labs/stader-node/shared/utils/stdr"
 )
 
@@ -484,8 +486,45 @@ func getWallet(c *cli.Context, cfg *config.StaderConfig, pm *passwords.PasswordM
 		if err != nil {
 			return
 		}
+		nodeWallet.SetTxPolicy(wallet.NewTxPolicy(
+			eth.EthToWei(cfg.StaderNode.TxFeeCap.Value.(float64)),
+			eth.EthToWei(cfg.StaderNode.DailyGasBudget.Value.(float64)),
+			os.ExpandEnv(cfg.StaderNode.GetGasSpendPath()),
+		))
+		nodeWallet.SetTxRecord(wallet.NewTxRecord(os.ExpandEnv(cfg.StaderNode.GetTxRecordPath())))
+		nodeWallet.SetTxJournal(wallet.NewTxJournal(os.ExpandEnv(cfg.StaderNode.GetTxJournalPath()), getTxJournalAction(c)))
+		nodeWallet.SetOfflineExport(c.GlobalBool("offline-export"))
+		nodeWallet.SetImportedKeyStore(wallet.NewImportedKeyStore(os.ExpandEnv(cfg.StaderNode.GetImportedKeyPath())))
+		if externalSignerUrl := cfg.StaderNode.ExternalSignerUrl.Value.(string); externalSignerUrl != "" {
+			var address *common.Address
+			if addressString := cfg.StaderNode.ExternalSignerAddress.Value.(string); addressString != "" {
+				if !common.IsHexAddress(addressString) {
+					err = fmt.Errorf("Invalid external signer address: %s", addressString)
+					return
+				}
+				parsedAddress := common.HexToAddress(addressString)
+				address = &parsedAddress
+			}
+			var externalSigner *wallet.ExternalSigner
+			externalSigner, err = wallet.NewExternalSigner(externalSignerUrl, address)
+			if err != nil {
+				return
+			}
+			nodeWallet.SetExternalSigner(externalSigner)
+		}
+		if addressString := cfg.StaderNode.WatchOnlyNodeAddress.Value.(string); addressString != "" {
+			if !common.IsHexAddress(addressString) {
+				err = fmt.Errorf("Invalid watch-only node address: %s", addressString)
+				return
+			}
+			nodeWallet.SetWatchOnlyAddress(common.HexToAddress(addressString))
+		}
 
-		// Keystores
+		// Keystores; a Web3Signer replaces the local ones
+		if web3SignerUrl := cfg.StaderNode.Web3SignerUrl.Value.(string); web3SignerUrl != "" {
+			nodeWallet.AddKeystore("web3signer", w3skeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), web3SignerUrl))
+			return
+		}
 		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
 		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
 		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
@@ -511,7 +550,7 @@ func getEthClient(c *cli.Context, cfg *config.StaderConfig) (*ExecutionClientMan
 				ecManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				ecManager.primaryReady = false
+				ecManager.endpoints[0].ready = false
 			}
 		}
 	})
@@ -529,7 +568,7 @@ func getBeaconClient(c *cli.Context, cfg *config.StaderConfig) (*BeaconClientMan
 				bcManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				bcManager.primaryReady = false
+				bcManager.endpoints[0].ready = false
 			}
 		}
 	})
@@ -543,3 +582,13 @@ func getDocker() (*client.Client, error) {
 	})
 	return docker, err
 }
+
+// Get the action that transactions signed by this process are journalled under.
+// API routes are subcommands such as "node deposit"; the daemons run as top-level commands such as "node", so they're labelled as daemons.
+func getTxJournalAction(c *cli.Context) string {
+	action := c.Command.FullName()
+	if !strings.Contains(action, " ") {
+		return action + " daemon"
+	}
+	return action
+}
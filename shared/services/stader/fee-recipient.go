@@ -24,6 +24,7 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/shared/services/config"
@@ -80,6 +81,22 @@ func UpdateFeeRecipientFile(feeRecipient common.Address, cfg *config.StaderConfi
 
 }
 
+// Reads the fee recipient the VC was last configured with from the fee recipient file
+func ReadFeeRecipientFile(cfg *config.StaderConfig) (common.Address, error) {
+	bytes, err := ioutil.ReadFile(cfg.StaderNode.GetFeeRecipientFilePath())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error reading fee recipient file: %w", err)
+	}
+	contents := strings.TrimSpace(string(bytes))
+	if cfg.IsNativeMode {
+		contents = strings.TrimPrefix(contents, config.FeeRecipientEnvVar+"=")
+	}
+	if !common.IsHexAddress(contents) {
+		return common.Address{}, fmt.Errorf("fee recipient file contains an invalid address: %s", contents)
+	}
+	return common.HexToAddress(contents), nil
+}
+
 // Gets the expected contents of the fee recipient file
 func getFeeRecipientFileContents(feeRecipient common.Address, cfg *config.StaderConfig) string {
 	if !cfg.IsNativeMode {
@@ -20,7 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package stader
 
 import (
+	"encoding/json"
 	"fmt"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
 )
 
 const (
@@ -35,3 +38,19 @@ func (sd *Client) PrintMultiTxWarning() {
 		colorReset)
 
 }
+
+// Get gas price suggestions from the Execution client's recent fee history
+func (c *Client) GetGasPrices() (api.GasPricesResponse, error) {
+	responseBytes, err := c.callAPI("node get-gas-prices")
+	if err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("could not get gas prices: %w", err)
+	}
+	var response api.GasPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("could not decode gas prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasPricesResponse{}, fmt.Errorf("could not get gas prices: %s", response.Error)
+	}
+	return response, nil
+}
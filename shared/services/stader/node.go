@@ -26,6 +26,7 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	string_utils "github.com/stader-labs/stader-node/shared/utils/string-utils"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 
@@ -207,8 +208,8 @@ func (c *Client) GetNodeDepositSdAllowance() (api.NodeDepositSdAllowanceResponse
 }
 
 // Check whether the node can make a deposit
-func (c *Client) CanNodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (api.CanNodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("validator can-deposit %s %s %t", amountWei.String(), numValidators, reloadKeys))
+func (c *Client) CanNodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool, useImportedKeys bool) (api.CanNodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator can-deposit %s %s %t %t", amountWei.String(), numValidators, reloadKeys, useImportedKeys))
 	if err != nil {
 		return api.CanNodeDepositResponse{}, fmt.Errorf("could not get can validator deposit status: %w", err)
 	}
@@ -252,6 +253,118 @@ func (c *Client) ExitValidator(validatorPubKey types.ValidatorPubkey) (api.ExitV
 	return response, nil
 }
 
+// Get the upcoming proposer and sync committee duties of the operator validators
+func (c *Client) GetValidatorDuties(epochs uint64) (api.ValidatorDutiesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator duties %d", epochs))
+	if err != nil {
+		return api.ValidatorDutiesResponse{}, fmt.Errorf("could not get validator duties: %w", err)
+	}
+	var response api.ValidatorDutiesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorDutiesResponse{}, fmt.Errorf("could not decode validator duties response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorDutiesResponse{}, fmt.Errorf("could not get validator duties: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the graffiti and gas limit of the operator validators and the configured ones
+func (c *Client) GetValidatorSettings() (api.ValidatorSettingsResponse, error) {
+	responseBytes, err := c.callAPI("validator settings")
+	if err != nil {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not get validator settings: %w", err)
+	}
+	var response api.ValidatorSettingsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not decode validator settings response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not get validator settings: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Apply the configured graffiti and gas limit to the operator validators, removing the overrides of the settings that were just cleared
+func (c *Client) ApplyValidatorSettings(clearGraffiti bool, clearGasLimit bool) (api.ValidatorSettingsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator apply-settings %t %t", clearGraffiti, clearGasLimit))
+	if err != nil {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not apply validator settings: %w", err)
+	}
+	var response api.ValidatorSettingsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not decode apply validator settings response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorSettingsResponse{}, fmt.Errorf("could not apply validator settings: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Compare the operator validators on chain with the wallet, the keystores and the validator client
+func (c *Client) ReconcileValidatorKeys(verifyKeys bool) (api.ValidatorReconcileResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator reconcile %t", verifyKeys))
+	if err != nil {
+		return api.ValidatorReconcileResponse{}, fmt.Errorf("could not reconcile validator keys: %w", err)
+	}
+	var response api.ValidatorReconcileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorReconcileResponse{}, fmt.Errorf("could not decode validator reconcile response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorReconcileResponse{}, fmt.Errorf("could not reconcile validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Remove the key of a validator with no more duties
+func (c *Client) RemoveValidatorKey(validatorPubKey types.ValidatorPubkey) (api.RemoveValidatorKeyResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator remove-key %s", validatorPubKey))
+	if err != nil {
+		return api.RemoveValidatorKeyResponse{}, fmt.Errorf("could not remove validator key: %w", err)
+	}
+	var response api.RemoveValidatorKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RemoveValidatorKeyResponse{}, fmt.Errorf("could not decode remove validator key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RemoveValidatorKeyResponse{}, fmt.Errorf("could not remove validator key: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Import the keystores staged in the custom keys folder as validator keys
+func (c *Client) ImportKeystores() (api.ImportKeystoresResponse, error) {
+	responseBytes, err := c.callAPI("validator import-keystores")
+	if err != nil {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not import keystores: %w", err)
+	}
+	var response api.ImportKeystoresResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not decode import keystores response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not import keystores: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Watch the operator validators for a number of epochs and report any that are live elsewhere
+func (c *Client) CheckForDoppelgangers(epochs uint64) (api.CheckForDoppelgangersResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator check-for-doppelgangers %d", epochs))
+	if err != nil {
+		return api.CheckForDoppelgangersResponse{}, fmt.Errorf("could not check for doppelgangers: %w", err)
+	}
+	var response api.CheckForDoppelgangersResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckForDoppelgangersResponse{}, fmt.Errorf("could not decode check for doppelgangers response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckForDoppelgangersResponse{}, fmt.Errorf("could not check for doppelgangers: %s", response.Error)
+	}
+	return response, nil
+}
+
 func (c *Client) GetContractsInfo() (api.ContractsInfoResponse, error) {
 	responseBytes, err := c.callAPI("node get-contracts-info")
 	if err != nil {
@@ -268,8 +381,8 @@ func (c *Client) GetContractsInfo() (api.ContractsInfoResponse, error) {
 }
 
 // Make a node deposit
-func (c *Client) NodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (api.NodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("validator deposit %s %s %t", amountWei.String(), numValidators, reloadKeys))
+func (c *Client) NodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool, useImportedKeys bool) (api.NodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator deposit %s %s %t %t", amountWei.String(), numValidators, reloadKeys, useImportedKeys))
 	if err != nil {
 		return api.NodeDepositResponse{}, fmt.Errorf("could not make validator deposit as er: %w", err)
 	}
@@ -634,3 +747,115 @@ func (c *Client) SetRewardAddress(operatorRewardAddress common.Address) (api.Set
 	}
 	return response, nil
 }
+
+// Get the pending and recent transactions sent by the node account
+func (c *Client) NodeTxList() (api.NodeTxListResponse, error) {
+	responseBytes, err := c.callAPI("node tx-list")
+	if err != nil {
+		return api.NodeTxListResponse{}, fmt.Errorf("could not get node transactions: %w", err)
+	}
+	var response api.NodeTxListResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxListResponse{}, fmt.Errorf("could not decode tx-list response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxListResponse{}, fmt.Errorf("could not get node transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a node account transaction can be sped up
+func (c *Client) CanNodeTxSpeedUp(hash common.Hash) (api.CanNodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-tx-speed-up %s", hash.Hex()))
+	if err != nil {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not check if the transaction can be sped up: %w", err)
+	}
+	var response api.CanNodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not decode can-tx-speed-up response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not check if the transaction can be sped up: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Resend a pending node account transaction with higher fees
+func (c *Client) NodeTxSpeedUp(hash common.Hash) (api.NodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node tx-speed-up %s", hash.Hex()))
+	if err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not speed up the transaction: %w", err)
+	}
+	var response api.NodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not decode tx-speed-up response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not speed up the transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a node account transaction can be cancelled
+func (c *Client) CanNodeTxCancel(hash common.Hash) (api.CanNodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-tx-cancel %s", hash.Hex()))
+	if err != nil {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not check if the transaction can be cancelled: %w", err)
+	}
+	var response api.CanNodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not decode can-tx-cancel response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeReplaceTxResponse{}, fmt.Errorf("could not check if the transaction can be cancelled: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a pending node account transaction
+func (c *Client) NodeTxCancel(hash common.Hash) (api.NodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node tx-cancel %s", hash.Hex()))
+	if err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not cancel the transaction: %w", err)
+	}
+	var response api.NodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not decode tx-cancel response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("could not cancel the transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the journal of transactions sent by the node account
+func (c *Client) NodeTxHistory() (api.NodeTxHistoryResponse, error) {
+	responseBytes, err := c.callAPI("node tx-history")
+	if err != nil {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("could not get node transaction history: %w", err)
+	}
+	var response api.NodeTxHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("could not decode tx-history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("could not get node transaction history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Send a node account transaction that was signed offline
+func (c *Client) NodeBroadcast(signedTx []byte) (api.NodeBroadcastResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node broadcast %s", hexutil.Encode(signedTx)))
+	if err != nil {
+		return api.NodeBroadcastResponse{}, fmt.Errorf("could not broadcast the transaction: %w", err)
+	}
+	var response api.NodeBroadcastResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeBroadcastResponse{}, fmt.Errorf("could not decode broadcast response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeBroadcastResponse{}, fmt.Errorf("could not broadcast the transaction: %s", response.Error)
+	}
+	return response, nil
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stader-labs/stader-node/shared/types/api"
 )
 
@@ -121,18 +122,34 @@ func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address,
 	return response, nil
 }
 
-// Rebuild wallet
-func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet rebuild-validator-keys")
+// Rebuild a consensus client's keystore from the mnemonic and the imported validator keys
+func (c *Client) RebuildKeystores(client string, force bool) (api.RebuildKeystoresResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet rebuild-keystores %s %t", client, force))
 	if err != nil {
-		return api.RebuildWalletResponse{}, fmt.Errorf("Could not rebuild wallet: %w", err)
+		return api.RebuildKeystoresResponse{}, fmt.Errorf("Could not rebuild keystores: %w", err)
 	}
-	var response api.RebuildWalletResponse
+	var response api.RebuildKeystoresResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
-		return api.RebuildWalletResponse{}, fmt.Errorf("Could not decode rebuild wallet response: %w", err)
+		return api.RebuildKeystoresResponse{}, fmt.Errorf("Could not decode rebuild keystores response: %w", err)
 	}
 	if response.Error != "" {
-		return api.RebuildWalletResponse{}, fmt.Errorf("Could not rebuild wallet: %s", response.Error)
+		return api.RebuildKeystoresResponse{}, fmt.Errorf("Could not rebuild keystores: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Move the wallet index the next validator key is derived from forward
+func (c *Client) SetNextValidatorIndex(index uint64) (api.SetNextValidatorIndexResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet set-next-index %d", index))
+	if err != nil {
+		return api.SetNextValidatorIndexResponse{}, fmt.Errorf("Could not set the next validator index: %w", err)
+	}
+	var response api.SetNextValidatorIndexResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetNextValidatorIndexResponse{}, fmt.Errorf("Could not decode set next validator index response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetNextValidatorIndexResponse{}, fmt.Errorf("Could not set the next validator index: %s", response.Error)
 	}
 	return response, nil
 }
@@ -200,3 +217,19 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Sign a node account transaction that was exported for offline signing, checking it was exported for this wallet's node account
+func (c *Client) SignTransaction(unsignedTx []byte, from common.Address) (api.SignTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet sign-tx %s %s", hexutil.Encode(unsignedTx), from.Hex()))
+	if err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %w", err)
+	}
+	var response api.SignTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not decode sign transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %s", response.Error)
+	}
+	return response, nil
+}
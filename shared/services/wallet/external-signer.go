@@ -0,0 +1,243 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// How long to wait for the external signer; Clef may be waiting for a person to approve the request
+const externalSignerTimeout = 5 * time.Minute
+
+// The message signed to recover the node account's public key from the external signer
+const pubkeyRecoveryMessage = "Stader node public key recovery"
+
+var ErrExternalSignerKey = errors.New("The node account key is held by the external signer")
+
+// A node account backend that delegates signing to an external Clef-compatible JSON-RPC signer,
+// so the node account key never touches this machine
+type ExternalSigner struct {
+	url     string
+	client  *rpc.Client
+	address *common.Address
+	pubkey  *ecdsa.PublicKey
+	lock    sync.Mutex
+}
+
+// The transaction arguments of account_signTransaction
+type externalSignerTxArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                hexutil.Big       `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+}
+
+// The response of account_signTransaction
+type externalSignerTxResponse struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Create a new external signer client.
+// If the address is nil, the signer must hold exactly one account, which is used as the node account.
+func NewExternalSigner(url string, address *common.Address) (*ExternalSigner, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("Could not connect to the external signer at %s: %w", url, err)
+	}
+	return &ExternalSigner{
+		url:     url,
+		client:  client,
+		address: address,
+	}, nil
+}
+
+// Use an external signer for the node account instead of the key derived from the wallet
+func (w *Wallet) SetExternalSigner(signer *ExternalSigner) {
+	w.externalSigner = signer
+}
+
+// Check if the node account is held by an external signer
+func (w *Wallet) UsesExternalSigner() bool {
+	return w.externalSigner != nil
+}
+
+// Get the node account held by the signer
+func (s *ExternalSigner) GetAccount() (accounts.Account, error) {
+	address, err := s.getAddress()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return accounts.Account{
+		Address: address,
+		URL: accounts.URL{
+			Scheme: "extapi",
+			Path:   s.url,
+		},
+	}, nil
+}
+
+// Sign a transaction from the node account.
+// The signed transaction is checked against the one that was requested, since the signer returns it in full.
+func (s *ExternalSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	address, err := s.getAddress()
+	if err != nil {
+		return nil, err
+	}
+	args := externalSignerTxArgs{
+		From:                 address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                hexutil.Big(*tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 tx.Data(),
+		ChainID:              (*hexutil.Big)(chainID),
+	}
+	if accessList := tx.AccessList(); len(accessList) > 0 {
+		args.AccessList = &accessList
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+	var response externalSignerTxResponse
+	if err := s.client.CallContext(ctx, &response, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("External signer could not sign the transaction: %w", err)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(response.Raw); err != nil {
+		return nil, fmt.Errorf("Could not decode the transaction signed by the external signer: %w", err)
+	}
+
+	// Make sure the signer signed what was asked for, from the right account
+	signer := types.LatestSignerForChainID(chainID)
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the sender of the transaction signed by the external signer: %w", err)
+	}
+	if sender != address {
+		return nil, fmt.Errorf("External signer signed the transaction with %s instead of the node account %s", sender.Hex(), address.Hex())
+	}
+	expectedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tx.GasTipCap(),
+		GasFeeCap:  tx.GasFeeCap(),
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	})
+	if signer.Hash(signedTx) != signer.Hash(expectedTx) {
+		return nil, errors.New("External signer returned a different transaction than the one it was asked to sign")
+	}
+	return signedTx, nil
+}
+
+// Sign a message from the node account with the EIP-191 personal message prefix.
+// The signature's V is 27 or 28, the same as SignMessage with a local key.
+func (s *ExternalSigner) SignMessage(message []byte) ([]byte, error) {
+	address, err := s.getAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+	var signature hexutil.Bytes
+	if err := s.client.CallContext(ctx, &signature, "account_signData", accounts.MimetypeTextPlain, address, hexutil.Bytes(message)); err != nil {
+		return nil, fmt.Errorf("External signer could not sign the message: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("External signer returned a signature of %d bytes instead of %d", len(signature), crypto.SignatureLength)
+	}
+
+	// Make sure it was signed by the node account
+	pubkey, err := recoverMessagePubkey(message, signature)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*pubkey) != address {
+		return nil, fmt.Errorf("External signer signed the message with %s instead of the node account %s", crypto.PubkeyToAddress(*pubkey).Hex(), address.Hex())
+	}
+	return signature, nil
+}
+
+// Get the public key of the node account, recovering it from a signature the first time.
+// Clef doesn't expose public keys, so it's asked to sign pubkeyRecoveryMessage once per process; it will prompt for that unless a rule approves it.
+func (s *ExternalSigner) GetPubkey() (*ecdsa.PublicKey, error) {
+	s.lock.Lock()
+	pubkey := s.pubkey
+	s.lock.Unlock()
+	if pubkey != nil {
+		return pubkey, nil
+	}
+
+	message := []byte(pubkeyRecoveryMessage)
+	signature, err := s.SignMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	pubkey, err = recoverMessagePubkey(message, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	s.pubkey = pubkey
+	s.lock.Unlock()
+	return pubkey, nil
+}
+
+// Get the node account address, asking the signer for its only account if it wasn't configured
+func (s *ExternalSigner) getAddress() (common.Address, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.address != nil {
+		return *s.address, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+	var addresses []common.Address
+	if err := s.client.CallContext(ctx, &addresses, "account_list"); err != nil {
+		return common.Address{}, fmt.Errorf("Could not list the external signer's accounts: %w", err)
+	}
+	if len(addresses) != 1 {
+		return common.Address{}, fmt.Errorf("External signer has %d accounts; set the external signer address to choose the node account", len(addresses))
+	}
+	s.address = &addresses[0]
+	return addresses[0], nil
+}
+
+// Recover the public key from a personal message signature with a V of 27 or 28
+func recoverMessagePubkey(message []byte, signature []byte) (*ecdsa.PublicKey, error) {
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	if err != nil {
+		return nil, fmt.Errorf("Could not recover the signer of the message: %w", err)
+	}
+	return pubkey, nil
+}
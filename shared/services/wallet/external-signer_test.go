@@ -0,0 +1,196 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	testChainID   = 1337
+	testSignerKey = "f7d400ec4062274059f531413e03a938fd837e3a07692338ab78dfd93d1e21e1"
+)
+
+// A Clef-compatible signer that signs everything with one key
+type mockSigner struct {
+	key    *ecdsa.PrivateKey
+	tamper bool
+}
+
+func (m *mockSigner) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(m.key.PublicKey)}
+}
+
+func (m *mockSigner) SignTransaction(args externalSignerTxArgs, methodSelector *string) (map[string]interface{}, error) {
+	value := args.Value.ToInt()
+	if m.tamper {
+		value = new(big.Int).Add(value, big.NewInt(1))
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     value,
+		Data:      args.Data,
+	})
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), m.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTx}, nil
+}
+
+func (m *mockSigner) SignData(contentType string, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	signature, err := crypto.Sign(accounts.TextHash(data), m.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+func newTestExternalSignerWallet(t *testing.T, tamper bool, address *common.Address) (*Wallet, *ecdsa.PrivateKey) {
+	key, err := crypto.HexToECDSA(testSignerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &mockSigner{key: key, tamper: tamper}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	signer, err := NewExternalSigner(httpServer.URL, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(t.TempDir(), "wallet"), testChainID, big.NewInt(100), big.NewInt(2), 21000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetExternalSigner(signer)
+	return w, key
+}
+
+func TestExternalSignerTransactor(t *testing.T) {
+	w, key := newTestExternalSignerWallet(t, false, nil)
+	nodeAddress := crypto.PubkeyToAddress(key.PublicKey)
+
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != nodeAddress {
+		t.Fatalf("node account is %s, expected %s", account.Address.Hex(), nodeAddress.Hex())
+	}
+
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(testChainID),
+		Nonce:     3,
+		GasTipCap: opts.GasTipCap,
+		GasFeeCap: opts.GasFeeCap,
+		Gas:       opts.GasLimit,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		Data:      []byte{0x12, 0x34},
+	})
+	signedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), signedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != nodeAddress {
+		t.Fatalf("transaction was signed by %s, expected %s", sender.Hex(), nodeAddress.Hex())
+	}
+
+	if _, err := opts.Signer(to, tx); err == nil {
+		t.Fatal("expected signing from another address to fail")
+	}
+}
+
+func TestExternalSignerRejectsTamperedTransaction(t *testing.T) {
+	w, _ := newTestExternalSignerWallet(t, true, nil)
+
+	to := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(testChainID),
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.SignNodeTransaction(tx, nodeAccount.Address); err == nil {
+		t.Fatal("expected a tampered transaction to be rejected")
+	}
+}
+
+func TestExternalSignerSignMessage(t *testing.T) {
+	w, key := newTestExternalSignerWallet(t, false, nil)
+
+	message := "stader"
+	signature, err := w.SignMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected[crypto.RecoveryIDOffset] += 27
+	if hexutil.Encode(signature) != hexutil.Encode(expected) {
+		t.Fatalf("signature is %s, expected %s", hexutil.Encode(signature), hexutil.Encode(expected))
+	}
+
+	pubkey, err := w.GetNodePubkey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pubkey != common.Bytes2Hex(crypto.FromECDSAPub(&key.PublicKey)) {
+		t.Fatalf("node pubkey is %s", pubkey)
+	}
+
+	if _, err := w.GetNodePrivateKey(); err != ErrExternalSignerKey {
+		t.Fatalf("expected ErrExternalSignerKey, got %v", err)
+	}
+}
+
+func TestExternalSignerWrongAddress(t *testing.T) {
+	otherAddress := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	w, _ := newTestExternalSignerWallet(t, false, &otherAddress)
+
+	if _, err := w.SignMessage("stader"); err == nil {
+		t.Fatal("expected a signature from another account to be rejected")
+	}
+}
@@ -0,0 +1,244 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+)
+
+// Keeps the validator keys that were imported instead of derived from the wallet's mnemonic.
+// Each key is an EIP-2335 keystore with a random password next to it, so the keys outlive the wallet password.
+type ImportedKeyStore struct {
+	path string
+	lock sync.Mutex
+
+	// Decrypted keys, since decrypting is slow and the daemon looks keys up on every pass
+	keys map[stadertypes.ValidatorPubkey]ValidatorKey
+}
+
+// An imported validator key on disk
+type importedKey struct {
+	Crypto  map[string]interface{}      `json:"crypto"`
+	Version uint                        `json:"version"`
+	UUID    uuid.UUID                   `json:"uuid"`
+	Path    string                      `json:"path"`
+	Pubkey  stadertypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Create a new imported key store
+func NewImportedKeyStore(path string) *ImportedKeyStore {
+	return &ImportedKeyStore{
+		path: path,
+		keys: map[stadertypes.ValidatorPubkey]ValidatorKey{},
+	}
+}
+
+// Set the store of imported validator keys
+func (w *Wallet) SetImportedKeyStore(store *ImportedKeyStore) {
+	w.importedKeys = store
+}
+
+// Decrypt an EIP-2335 keystore generated outside the wallet
+func (w *Wallet) DecryptValidatorKeystore(keystoreBytes []byte, password string) (ValidatorKey, error) {
+
+	// Initialize BLS support
+	if err := initializeBLS(); err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	key, derivationPath, err := keystore.DecryptKeystore(keystoreBytes, password)
+	if err != nil {
+		return ValidatorKey{}, err
+	}
+	return ValidatorKey{
+		PublicKey:      stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+		PrivateKey:     key,
+		DerivationPath: derivationPath,
+	}, nil
+
+}
+
+// Import a validator key that wasn't derived from the wallet, and store it in every keystore.
+// Importing a key again only stores it in the keystores again.
+func (w *Wallet) ImportValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	if w.importedKeys == nil {
+		return errors.New("Wallet has no imported key store")
+	}
+	if err := w.importedKeys.save(key, derivationPath, w.encryptor); err != nil {
+		return err
+	}
+	return w.StoreValidatorKey(key, derivationPath)
+
+}
+
+// Get the pubkeys of the imported validator keys without decrypting them
+func (w *Wallet) GetImportedValidatorPubkeys() ([]stadertypes.ValidatorPubkey, error) {
+	if w.importedKeys == nil {
+		return []stadertypes.ValidatorPubkey{}, nil
+	}
+	w.importedKeys.lock.Lock()
+	defer w.importedKeys.lock.Unlock()
+	return keystore.ListValidatorEntries(w.importedKeys.path, ".json")
+}
+
+// Get an imported validator key; returns an error wrapping keystore.ErrValidatorKeyNotFound if it wasn't imported.
+// Imported keys have no wallet index.
+func (w *Wallet) GetImportedValidatorKey(pubkey stadertypes.ValidatorPubkey) (ValidatorKey, error) {
+
+	if w.importedKeys == nil {
+		return ValidatorKey{}, fmt.Errorf("%w: validator %s wasn't imported", keystore.ErrValidatorKeyNotFound, pubkey.Hex())
+	}
+
+	// Initialize BLS support
+	if err := initializeBLS(); err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	w.importedKeys.lock.Lock()
+	defer w.importedKeys.lock.Unlock()
+	return w.importedKeys.load(pubkey)
+
+}
+
+// Get every imported validator key
+func (w *Wallet) GetImportedValidatorKeys() ([]ValidatorKey, error) {
+
+	pubkeys, err := w.GetImportedValidatorPubkeys()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]ValidatorKey, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		key, err := w.GetImportedValidatorKey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+
+}
+
+// Forget an imported validator key; this doesn't remove it from the keystores.
+// Forgetting a key that wasn't imported isn't an error.
+func (w *Wallet) DeleteImportedValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	if w.importedKeys == nil {
+		return nil
+	}
+	w.importedKeys.lock.Lock()
+	defer w.importedKeys.lock.Unlock()
+
+	delete(w.importedKeys.keys, pubkey)
+	keyFilePath, secretFilePath := w.importedKeys.getFilePaths(pubkey)
+	if err := os.Remove(keyFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete imported validator key: %w", err)
+	}
+	if err := os.Remove(secretFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete imported validator secret: %w", err)
+	}
+	return nil
+
+}
+
+// Get the paths of the keystore and password files of an imported key
+func (s *ImportedKeyStore) getFilePaths(pubkey stadertypes.ValidatorPubkey) (string, string) {
+	name := hexutil.AddPrefix(pubkey.Hex())
+	return filepath.Join(s.path, name+".json"), filepath.Join(s.path, name+".txt")
+}
+
+// Encrypt a key with a new random password and write it; a key that's already stored is left as it is
+func (s *ImportedKeyStore) save(key *eth2types.BLSPrivateKey, derivationPath string, encryptor *eth2ks.Encryptor) error {
+
+	// Get validator pubkey
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keyFilePath, secretFilePath := s.getFilePaths(pubkey)
+	if _, err := os.Stat(keyFilePath); err == nil {
+		return nil
+	}
+
+	// Create a new password and encrypt the key with it
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+	keyBytes, err := json.Marshal(importedKey{
+		Crypto:  encryptedKey,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Write the password and then the key
+	if err := os.MkdirAll(s.path, 0700); err != nil {
+		return fmt.Errorf("Could not create imported validator key folder: %w", err)
+	}
+	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write imported validator secret to disk: %w", err)
+	}
+	if err := writeFileAtomic(keyFilePath, keyBytes); err != nil {
+		return fmt.Errorf("Could not write imported validator key to disk: %w", err)
+	}
+	return nil
+
+}
+
+// Read and decrypt an imported key, or get it from the cache
+func (s *ImportedKeyStore) load(pubkey stadertypes.ValidatorPubkey) (ValidatorKey, error) {
+
+	if key, ok := s.keys[pubkey]; ok {
+		return key, nil
+	}
+
+	keyFilePath, secretFilePath := s.getFilePaths(pubkey)
+	keyBytes, err := ioutil.ReadFile(keyFilePath)
+	if os.IsNotExist(err) {
+		return ValidatorKey{}, fmt.Errorf("%w: validator %s wasn't imported", keystore.ErrValidatorKeyNotFound, pubkey.Hex())
+	} else if err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not read imported validator key %s: %w", pubkey.Hex(), err)
+	}
+	password, err := ioutil.ReadFile(secretFilePath)
+	if err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not read imported validator secret %s: %w", pubkey.Hex(), err)
+	}
+
+	key, derivationPath, err := keystore.DecryptKeystore(keyBytes, string(password))
+	if err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not load imported validator key %s: %w", pubkey.Hex(), err)
+	}
+	if keyPubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()); keyPubkey != pubkey {
+		return ValidatorKey{}, fmt.Errorf("Imported validator key %s holds the key for validator %s", pubkey.Hex(), keyPubkey.Hex())
+	}
+	s.keys[pubkey] = ValidatorKey{
+		PublicKey:      pubkey,
+		PrivateKey:     key,
+		DerivationPath: derivationPath,
+	}
+	return s.keys[pubkey], nil
+
+}
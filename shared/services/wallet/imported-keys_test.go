@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+// Make an EIP-2335 keystore the way the staking deposit CLI does
+func newTestKeystore(t *testing.T, password string, pubkey string) (*eth2types.BLSPrivateKey, []byte) {
+	if err := initializeBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor := eth2ks.New(eth2ks.WithCipher("pbkdf2"))
+	crypto, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pubkey == "" {
+		pubkey = stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex()
+	}
+	keystoreBytes, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"version": encryptor.Version(),
+		"path":    "m/12381/3600/7/0/0",
+		"pubkey":  pubkey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, keystoreBytes
+}
+
+func TestImportValidatorKey(t *testing.T) {
+	w := &Wallet{
+		encryptor: eth2ks.New(),
+		keystores: map[string]keystore.Keystore{},
+	}
+	w.SetImportedKeyStore(NewImportedKeyStore(filepath.Join(t.TempDir(), "imported-keys")))
+
+	key, keystoreBytes := newTestKeystore(t, "correct horse", "")
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	if _, err := w.DecryptValidatorKeystore(keystoreBytes, "wrong horse"); err == nil {
+		t.Fatal("decrypted a keystore with the wrong password")
+	}
+	validatorKey, err := w.DecryptValidatorKeystore(keystoreBytes, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validatorKey.PublicKey != pubkey || validatorKey.DerivationPath != "m/12381/3600/7/0/0" {
+		t.Fatalf("decrypted %s at %s", validatorKey.PublicKey.Hex(), validatorKey.DerivationPath)
+	}
+
+	// Importing twice keeps one copy
+	for i := 0; i < 2; i++ {
+		if err := w.ImportValidatorKey(validatorKey.PrivateKey, validatorKey.DerivationPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pubkeys, err := w.GetImportedValidatorPubkeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || pubkeys[0] != pubkey {
+		t.Fatalf("imported %v", pubkeys)
+	}
+	imported, err := w.GetImportedValidatorKey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stadertypes.BytesToValidatorPubkey(imported.PrivateKey.PublicKey().Marshal()) != pubkey || imported.DerivationPath != validatorKey.DerivationPath {
+		t.Fatalf("loaded %s at %s", imported.PublicKey.Hex(), imported.DerivationPath)
+	}
+
+	if err := w.DeleteImportedValidatorKey(pubkey); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.DeleteImportedValidatorKey(pubkey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetImportedValidatorKey(pubkey); !errors.Is(err, keystore.ErrValidatorKeyNotFound) {
+		t.Fatalf("got %v for a forgotten key", err)
+	}
+}
+
+func TestDecryptMislabelledKeystore(t *testing.T) {
+	w := &Wallet{}
+	otherKey, _ := newTestKeystore(t, "password", "")
+	_, keystoreBytes := newTestKeystore(t, "password", stadertypes.BytesToValidatorPubkey(otherKey.PublicKey().Marshal()).Hex())
+	if _, err := w.DecryptValidatorKeystore(keystoreBytes, "password"); err == nil {
+		t.Fatal("decrypted a keystore labelled with another validator's pubkey")
+	}
+}
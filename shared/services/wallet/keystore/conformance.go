@@ -0,0 +1,138 @@
+package keystore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Run the tests every keystore implementation must pass. newKeystore opens the keystore in a directory;
+// opening the same directory again must read back what was written there.
+func RunConformanceTests(t *testing.T, newKeystore func(dir string) Keystore) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("delete validator key", func(t *testing.T) {
+		dir := t.TempDir()
+		pubkeys := storeTestKeys(t, newKeystore(dir), 3)
+		if err := newKeystore(dir).DeleteValidatorKey(pubkeys[1]); err != nil {
+			t.Fatal(err)
+		}
+
+		// Read the keystore back from disk
+		ks := newKeystore(dir)
+		listed, err := ks.ListValidatorKeys()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(listed) != 2 {
+			t.Fatalf("expected 2 keys to be left, got %d", len(listed))
+		}
+		for _, pubkey := range []stadertypes.ValidatorPubkey{pubkeys[0], pubkeys[2]} {
+			if err := ks.VerifyValidatorKey(pubkey); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := ks.VerifyValidatorKey(pubkeys[1]); !errors.Is(err, ErrValidatorKeyNotFound) {
+			t.Fatalf("expected the deleted key to be missing, got %v", err)
+		}
+
+		// Deleting it again changes nothing
+		if err := ks.DeleteValidatorKey(pubkeys[1]); err != nil {
+			t.Fatal(err)
+		}
+		if listed, err := newKeystore(dir).ListValidatorKeys(); err != nil || len(listed) != 2 {
+			t.Fatalf("expected 2 keys after deleting a missing one, got %v (%v)", listed, err)
+		}
+	})
+
+	t.Run("corrupted key", func(t *testing.T) {
+		dir := t.TempDir()
+		pubkeys := storeTestKeys(t, newKeystore(dir), 2)
+
+		// Corrupt the files stored for the key, or every file if the keys share a store
+		files := findKeyFiles(t, dir, pubkeys[0])
+		if len(files) == 0 {
+			files = findKeyFiles(t, dir, stadertypes.ValidatorPubkey{})
+		}
+		for _, file := range files {
+			if err := ioutil.WriteFile(file, []byte("corrupted"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := newKeystore(dir).VerifyValidatorKey(pubkeys[0])
+		if err == nil || errors.Is(err, ErrValidatorKeyNotFound) {
+			t.Fatalf("expected a corrupted key to fail verification without being reported as missing, got %v", err)
+		}
+	})
+
+	t.Run("mismatched key", func(t *testing.T) {
+		dir := t.TempDir()
+		pubkeys := storeTestKeys(t, newKeystore(dir), 2)
+		files := findKeyFiles(t, dir, pubkeys[0])
+		if len(files) == 0 {
+			t.Skip("keys aren't stored in files named after their validators")
+		}
+
+		// Swap the files of the two validators, so each one's key is stored under the other
+		for _, file := range files {
+			other := strings.Replace(file, pubkeys[0].Hex(), pubkeys[1].Hex(), -1)
+			swapped := file + ".swap"
+			for _, rename := range [][2]string{{file, swapped}, {other, file}, {swapped, other}} {
+				if err := os.Rename(rename[0], rename[1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		ks := newKeystore(dir)
+		for _, pubkey := range pubkeys {
+			err := ks.VerifyValidatorKey(pubkey)
+			if err == nil || errors.Is(err, ErrValidatorKeyNotFound) {
+				t.Fatalf("expected the key stored under %s to be rejected, got %v", pubkey.Hex(), err)
+			}
+		}
+	})
+}
+
+// Store new validator keys, returning their pubkeys
+func storeTestKeys(t *testing.T, ks Keystore, count int) []stadertypes.ValidatorPubkey {
+	pubkeys := make([]stadertypes.ValidatorPubkey, count)
+	for i := range pubkeys {
+		key, err := eth2types.GenerateBLSPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.StoreValidatorKey(key, ""); err != nil {
+			t.Fatal(err)
+		}
+		pubkeys[i] = stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	}
+	return pubkeys
+}
+
+// Find the files in a keystore directory whose paths are named after a validator; an empty pubkey matches every file
+func findKeyFiles(t *testing.T, dir string, pubkey stadertypes.ValidatorPubkey) []string {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && (pubkey == stadertypes.ValidatorPubkey{} || strings.Contains(path, pubkey.Hex())) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -20,10 +20,24 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package keystore
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
 	"github.com/sethvargo/go-password/password"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
 )
 
+// Returned when a keystore doesn't hold a validator key
+var ErrValidatorKeyNotFound = errors.New("validator key not found")
+
 // Generates a random password
 func GenerateRandomPassword() (string, error) {
 
@@ -40,4 +54,109 @@ func GenerateRandomPassword() (string, error) {
 type Keystore interface {
 	StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
 	GetKeystoreDir() string
+
+	// List the pubkeys of the validator keys in the keystore
+	ListValidatorKeys() ([]stadertypes.ValidatorPubkey, error)
+
+	// Delete a validator key from the keystore; deleting a key it doesn't hold isn't an error
+	DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error
+
+	// Check that the validator client will load the key for a validator from the keystore.
+	// Returns an error wrapping ErrValidatorKeyNotFound if the keystore doesn't hold it.
+	VerifyValidatorKey(pubkey stadertypes.ValidatorPubkey) error
+}
+
+// List the validators named by the entries of a directory, such as "0x<pubkey>" or "0x<pubkey>.json".
+// Entries that aren't named after a validator are ignored, as is a missing directory.
+func ListValidatorEntries(dir string, suffix string) ([]stadertypes.ValidatorPubkey, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []stadertypes.ValidatorPubkey{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read %s: %w", dir, err)
+	}
+
+	pubkeys := []stadertypes.ValidatorPubkey{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "0x") || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		pubkey, err := stadertypes.HexToValidatorPubkey(hexutil.RemovePrefix(strings.TrimSuffix(name, suffix)))
+		if err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Decrypt an EIP-2335 keystore, returning the validator key and its derivation path.
+// A pubkey in the keystore that doesn't match the decrypted key is an error.
+func DecryptKeystore(keystoreBytes []byte, password string) (*eth2types.BLSPrivateKey, string, error) {
+
+	// Decode the keystore
+	var keystore struct {
+		Crypto map[string]interface{} `json:"crypto"`
+		Path   string                 `json:"path"`
+		Pubkey string                 `json:"pubkey"`
+	}
+	if err := json.Unmarshal(keystoreBytes, &keystore); err != nil {
+		return nil, "", fmt.Errorf("Could not decode keystore: %w", err)
+	}
+	if keystore.Crypto == nil {
+		return nil, "", errors.New("Keystore has no crypto section")
+	}
+
+	// Decrypt the key
+	secret, err := eth2ks.New().Decrypt(keystore.Crypto, password)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not decrypt keystore: %w", err)
+	}
+	privateKey, err := eth2types.BLSPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("Keystore doesn't hold a valid key: %w", err)
+	}
+
+	// Check it's the key the keystore is labelled with
+	if keystore.Pubkey != "" {
+		keystorePubkey, err := stadertypes.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.Pubkey))
+		if err != nil {
+			return nil, "", fmt.Errorf("Keystore has an invalid pubkey: %w", err)
+		}
+		if keyPubkey := stadertypes.BytesToValidatorPubkey(privateKey.PublicKey().Marshal()); keyPubkey != keystorePubkey {
+			return nil, "", fmt.Errorf("Keystore is labelled as validator %s but holds the key for validator %s", keystorePubkey.Hex(), keyPubkey.Hex())
+		}
+	}
+	return privateKey, keystore.Path, nil
+
+}
+
+// Check that an EIP-2335 keystore file decrypts with the password in a secret file to the key for a validator
+func VerifyKeystoreFile(keyFilePath string, secretFilePath string, pubkey stadertypes.ValidatorPubkey) error {
+
+	// Read the keystore
+	keystoreBytes, err := ioutil.ReadFile(keyFilePath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s doesn't exist", ErrValidatorKeyNotFound, keyFilePath)
+	} else if err != nil {
+		return fmt.Errorf("Could not read %s: %w", keyFilePath, err)
+	}
+
+	// Read the password
+	passwordBytes, err := ioutil.ReadFile(secretFilePath)
+	if err != nil {
+		return fmt.Errorf("Could not read the password for %s: %w", keyFilePath, err)
+	}
+
+	// Decrypt the key and check it's the validator's
+	privateKey, _, err := DecryptKeystore(keystoreBytes, string(passwordBytes))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyFilePath, err)
+	}
+	if keyPubkey := stadertypes.BytesToValidatorPubkey(privateKey.PublicKey().Marshal()); keyPubkey != pubkey {
+		return fmt.Errorf("%s holds the key for validator %s", keyFilePath, keyPubkey.Hex())
+	}
+	return nil
+
 }
@@ -30,6 +30,7 @@ import (
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"gopkg.in/yaml.v2"
 
 	"github.com/stader-labs/stader-node/shared/services/passwords"
 	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
@@ -44,6 +45,14 @@ const (
 	KeyFileName   = "voting-keystore.json"
 	DirMode       = 0770
 	FileMode      = 0640
+
+	DefinitionsFileName = "validator_definitions.yml"
+)
+
+// Validator definition fields
+const (
+	votingPublicKeyField = "voting_public_key"
+	enabledField         = "enabled"
 )
 
 // Lighthouse keystore
@@ -139,3 +148,96 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// List the validator keys in the keystore
+func (ks *Keystore) ListValidatorKeys() ([]stadertypes.ValidatorPubkey, error) {
+	return keystore.ListValidatorEntries(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir), "")
+}
+
+// Delete a validator key and its secret
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Drop the key from Lighthouse's definitions first, since Lighthouse won't start with a definition whose key is missing
+	if err := ks.deleteDefinition(pubkey); err != nil {
+		return err
+	}
+
+	// Delete the key before its secret so the validator client never finds a key without its secret
+	keyPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(keyPath); err != nil {
+		return fmt.Errorf("Could not delete validator key %s: %w", pubkey.Hex(), err)
+	}
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.Remove(secretFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete validator secret %s: %w", pubkey.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Check that a validator key decrypts with its secret to the key for the validator
+func (ks *Keystore) VerifyValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName)
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := keystore.VerifyKeystoreFile(keyFilePath, secretFilePath, pubkey); err != nil {
+		return err
+	}
+
+	// Lighthouse skips keys whose definition is disabled
+	definitions, err := ks.loadDefinitions()
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		if definition[votingPublicKeyField] == hexutil.AddPrefix(pubkey.Hex()) && definition[enabledField] == false {
+			return fmt.Errorf("validator key %s is disabled in %s", pubkey.Hex(), DefinitionsFileName)
+		}
+	}
+	return nil
+}
+
+// Load the validator definitions Lighthouse keeps for the keys it has found
+func (ks *Keystore) loadDefinitions() ([]map[string]interface{}, error) {
+	definitionsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, DefinitionsFileName)
+	definitions := []map[string]interface{}{}
+	definitionsBytes, err := ioutil.ReadFile(definitionsPath)
+	if os.IsNotExist(err) {
+		return definitions, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read %s: %w", definitionsPath, err)
+	}
+	if err := yaml.Unmarshal(definitionsBytes, &definitions); err != nil {
+		return nil, fmt.Errorf("Could not decode %s: %w", definitionsPath, err)
+	}
+	return definitions, nil
+}
+
+// Remove the definition of a validator key, keeping Lighthouse's fields of the others as they are
+func (ks *Keystore) deleteDefinition(pubkey stadertypes.ValidatorPubkey) error {
+	definitions, err := ks.loadDefinitions()
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]map[string]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition[votingPublicKeyField] != hexutil.AddPrefix(pubkey.Hex()) {
+			remaining = append(remaining, definition)
+		}
+	}
+	if len(remaining) == len(definitions) {
+		return nil
+	}
+
+	definitionsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, DefinitionsFileName)
+	definitionsBytes, err := yaml.Marshal(remaining)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator definitions: %w", err)
+	}
+	if err := ioutil.WriteFile(definitionsPath, definitionsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write %s: %w", definitionsPath, err)
+	}
+	return nil
+}
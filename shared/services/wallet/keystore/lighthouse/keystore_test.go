@@ -0,0 +1,80 @@
+package lighthouse
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+)
+
+func TestKeystore(t *testing.T) {
+	keystore.RunConformanceTests(t, func(dir string) keystore.Keystore {
+		return NewKeystore(dir, nil)
+	})
+}
+
+// Lighthouse's definitions file has fields of its own that must survive deleting a key
+func TestDeleteValidatorKeyDefinitions(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	ks := NewKeystore(t.TempDir(), nil)
+
+	pubkeys := make([]stadertypes.ValidatorPubkey, 3)
+	for i := range pubkeys {
+		key, err := eth2types.GenerateBLSPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.StoreValidatorKey(key, ""); err != nil {
+			t.Fatal(err)
+		}
+		pubkeys[i] = stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	}
+
+	// Lighthouse defines each key it finds, with fields of its own that must survive the rewrite
+	definitions := make([]map[string]interface{}, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		definitions[i] = map[string]interface{}{
+			enabledField:           true,
+			votingPublicKeyField:   hexutil.AddPrefix(pubkey.Hex()),
+			"description":          "",
+			"type":                 "local_keystore",
+			"voting_keystore_path": filepath.Join(ks.GetKeystoreDir(), ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName),
+		}
+	}
+	definitionsBytes, err := yaml.Marshal(definitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(ks.GetKeystoreDir(), ValidatorsDir, DefinitionsFileName), definitionsBytes, FileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ks.DeleteValidatorKey(pubkeys[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the deleted key's definition is gone
+	remaining, err := ks.loadDefinitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 definitions to be left, got %d", len(remaining))
+	}
+	for i, pubkey := range []stadertypes.ValidatorPubkey{pubkeys[0], pubkeys[2]} {
+		if remaining[i][votingPublicKeyField] != hexutil.AddPrefix(pubkey.Hex()) {
+			t.Fatalf("definition %d is for %v instead of %s", i, remaining[i][votingPublicKeyField], pubkey.Hex())
+		}
+		if remaining[i]["type"] != "local_keystore" || remaining[i][enabledField] != true {
+			t.Fatalf("definition %d lost Lighthouse's fields: %v", i, remaining[i])
+		}
+	}
+}
@@ -181,3 +181,33 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return privateKey, nil
 
 }
+
+// List the validator keys in the keystore
+func (ks *Keystore) ListValidatorKeys() ([]stadertypes.ValidatorPubkey, error) {
+	return keystore.ListValidatorEntries(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir), "")
+}
+
+// Delete a validator key and its secret
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Delete the key first so the validator client never finds a key without its secret
+	keyPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(keyPath); err != nil {
+		return fmt.Errorf("Could not delete validator key %s: %w", pubkey.Hex(), err)
+	}
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.Remove(secretFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete validator secret %s: %w", pubkey.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Check that a validator key decrypts with its secret to the key for the validator
+func (ks *Keystore) VerifyValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName)
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	return keystore.VerifyKeystoreFile(keyFilePath, secretFilePath, pubkey)
+}
@@ -0,0 +1,52 @@
+package lodestar
+
+import (
+	"bytes"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+func TestKeystore(t *testing.T) {
+	keystore.RunConformanceTests(t, func(dir string) keystore.Keystore {
+		return NewKeystore(dir, nil)
+	})
+}
+
+func TestLoadValidatorKey(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	ks := NewKeystore(t.TempDir(), nil)
+
+	keys := make([]*eth2types.BLSPrivateKey, 2)
+	pubkeys := make([]stadertypes.ValidatorPubkey, 2)
+	for i := range keys {
+		key, err := eth2types.GenerateBLSPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.StoreValidatorKey(key, ""); err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+		pubkeys[i] = stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	}
+	if err := ks.DeleteValidatorKey(pubkeys[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ks.LoadValidatorKey(pubkeys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key == nil || !bytes.Equal(key.Marshal(), keys[0].Marshal()) {
+		t.Fatalf("key %s didn't load back", pubkeys[0].Hex())
+	}
+	if key, err := ks.LoadValidatorKey(pubkeys[1]); err != nil || key != nil {
+		t.Fatalf("expected the deleted key not to load, got %v (%v)", key, err)
+	}
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/google/uuid"
 	staderkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
@@ -109,6 +110,94 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	ks.as.PrivateKeys = append(ks.as.PrivateKeys, key.Marshal())
 	ks.as.PublicKeys = append(ks.as.PublicKeys, key.PublicKey().Marshal())
 
+	// Save it
+	return ks.save()
+
+}
+
+// List the validator keys in the account store
+func (ks *Keystore) ListValidatorKeys() ([]stadertypes.ValidatorPubkey, error) {
+
+	// Don't create a wallet just to list it
+	if !ks.exists() {
+		return []stadertypes.ValidatorPubkey{}, nil
+	}
+	if err := ks.initialize(); err != nil {
+		return nil, err
+	}
+
+	pubkeys := make([]stadertypes.ValidatorPubkey, len(ks.as.PublicKeys))
+	for ki, pubkey := range ks.as.PublicKeys {
+		pubkeys[ki] = stadertypes.BytesToValidatorPubkey(pubkey)
+	}
+	return pubkeys, nil
+
+}
+
+// Delete a validator key from the account store
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	if !ks.exists() {
+		return nil
+	}
+	if err := ks.initialize(); err != nil {
+		return err
+	}
+
+	ki := ks.indexOf(pubkey)
+	if ki == -1 {
+		return nil
+	}
+	ks.as.PrivateKeys = append(ks.as.PrivateKeys[:ki], ks.as.PrivateKeys[ki+1:]...)
+	ks.as.PublicKeys = append(ks.as.PublicKeys[:ki], ks.as.PublicKeys[ki+1:]...)
+	return ks.save()
+
+}
+
+// Check that the account store holds the key for a validator
+func (ks *Keystore) VerifyValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	if !ks.exists() {
+		return fmt.Errorf("%w: the Prysm wallet doesn't exist", staderkeystore.ErrValidatorKeyNotFound)
+	}
+	if err := ks.initialize(); err != nil {
+		return err
+	}
+
+	ki := ks.indexOf(pubkey)
+	if ki == -1 {
+		return fmt.Errorf("%w: the Prysm wallet doesn't hold validator %s", staderkeystore.ErrValidatorKeyNotFound, pubkey.Hex())
+	}
+	privateKey, err := eth2types.BLSPrivateKeyFromBytes(ks.as.PrivateKeys[ki])
+	if err != nil {
+		return fmt.Errorf("the Prysm wallet holds an invalid key for validator %s: %w", pubkey.Hex(), err)
+	}
+	if keyPubkey := stadertypes.BytesToValidatorPubkey(privateKey.PublicKey().Marshal()); keyPubkey != pubkey {
+		return fmt.Errorf("the Prysm wallet holds the key for validator %s under validator %s", keyPubkey.Hex(), pubkey.Hex())
+	}
+	return nil
+
+}
+
+// Check whether the account store has been written
+func (ks *Keystore) exists() bool {
+	_, err := os.Stat(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName))
+	return err == nil
+}
+
+// Get the position of a validator in the account store, or -1 if it isn't in it
+func (ks *Keystore) indexOf(pubkey stadertypes.ValidatorPubkey) int {
+	for ki := 0; ki < len(ks.as.PublicKeys); ki++ {
+		if bytes.Equal(pubkey.Bytes(), ks.as.PublicKeys[ki]) {
+			return ki
+		}
+	}
+	return -1
+}
+
+// Encrypt the account store and write it to disk
+func (ks *Keystore) save() error {
+
 	// Encode account store
 	asBytes, err := json.Marshal(ks.as)
 	if err != nil {
@@ -0,0 +1,61 @@
+package prysm
+
+import (
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	staderkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+func TestKeystore(t *testing.T) {
+	staderkeystore.RunConformanceTests(t, func(dir string) staderkeystore.Keystore {
+		return NewKeystore(dir, nil)
+	})
+}
+
+// Every key lives in one encrypted account store, so deleting one rewrites the others too
+func TestDeleteValidatorKeyKeepsPairs(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	ks := NewKeystore(dir, nil)
+
+	pubkeys := make([]stadertypes.ValidatorPubkey, 3)
+	for i := range pubkeys {
+		key, err := eth2types.GenerateBLSPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.StoreValidatorKey(key, ""); err != nil {
+			t.Fatal(err)
+		}
+		pubkeys[i] = stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	}
+	if err := ks.DeleteValidatorKey(pubkeys[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the rewritten account store back from disk
+	reopened := NewKeystore(dir, nil)
+	listed, err := reopened.ListValidatorKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || listed[0] != pubkeys[0] || listed[1] != pubkeys[2] {
+		t.Fatalf("expected %s and %s to be left, got %v", pubkeys[0].Hex(), pubkeys[2].Hex(), listed)
+	}
+
+	// The private keys stayed paired with their public keys
+	for ki, privateKey := range reopened.as.PrivateKeys {
+		key, err := eth2types.BLSPrivateKeyFromBytes(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()) != listed[ki] {
+			t.Fatalf("private key %d doesn't belong to %s", ki, listed[ki].Hex())
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package teku
+
+import (
+	"testing"
+
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+func TestKeystore(t *testing.T) {
+	keystore.RunConformanceTests(t, func(dir string) keystore.Keystore {
+		return NewKeystore(dir, nil)
+	})
+}
@@ -0,0 +1,289 @@
+package web3signer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	hex "github.com/stader-labs/stader-node/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoreDir         = "web3signer"
+	LighthouseDir       = "lighthouse"
+	DefinitionsFileName = "validator_definitions.yml"
+	DirMode             = 0770
+	FileMode            = 0640
+)
+
+// Web3Signer keystore.
+// Keys are imported into a Web3Signer instance through its keymanager API instead of being written to disk;
+// the only local state is the remote key definitions for Lighthouse, which can't fetch the key list from the signer.
+type Keystore struct {
+	keystorePath string
+	url          string
+	client       *keymanager.Client
+}
+
+// The fork a message is signed for
+type ForkInfo struct {
+	PreviousVersion       []byte
+	CurrentVersion        []byte
+	Epoch                 uint64
+	GenesisValidatorsRoot []byte
+}
+
+// Web3Signer signing request for a voluntary exit
+type voluntaryExitSignRequest struct {
+	Type     string `json:"type"`
+	ForkInfo struct {
+		Fork struct {
+			PreviousVersion string `json:"previous_version"`
+			CurrentVersion  string `json:"current_version"`
+			Epoch           string `json:"epoch"`
+		} `json:"fork"`
+		GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	} `json:"fork_info"`
+	SigningRoot   string `json:"signingRoot"`
+	VoluntaryExit struct {
+		Epoch          string `json:"epoch"`
+		ValidatorIndex string `json:"validator_index"`
+	} `json:"voluntary_exit"`
+}
+
+// Create new web3signer keystore
+func NewKeystore(keystorePath string, url string) *Keystore {
+	return &Keystore{
+		keystorePath: keystorePath,
+		url:          strings.TrimSuffix(url, "/"),
+		client:       keymanager.NewClient(url, ""),
+	}
+}
+
+// Get the keystore directory
+func (ks *Keystore) GetKeystoreDir() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir)
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Import it into Web3Signer
+	keyStore, password, err := keymanager.EncryptKeystore(key, derivationPath)
+	if err != nil {
+		return err
+	}
+	statuses, err := ks.client.ImportKeystores([]string{keyStore}, []string{password}, "")
+	if err != nil {
+		return fmt.Errorf("Could not import validator key %s into Web3Signer: %w", pubkey.Hex(), err)
+	}
+	if status := statuses[0]; status.Status != keymanager.StatusImported && status.Status != keymanager.StatusDuplicate {
+		return fmt.Errorf("Web3Signer could not import validator key %s: %s %s", pubkey.Hex(), status.Status, status.Message)
+	}
+
+	// Point Lighthouse at the signer for this key
+	if err := ks.storeLighthouseDefinition(pubkey); err != nil {
+		return fmt.Errorf("Could not add validator key %s to the Lighthouse remote key definitions: %w", pubkey.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Sign a voluntary exit message with a key held by Web3Signer.
+// The returned signature is checked against the validator pubkey and signing root.
+func (ks *Keystore) SignVoluntaryExit(pubkey stadertypes.ValidatorPubkey, forkInfo ForkInfo, epoch uint64, validatorIndex uint64, signingRoot [32]byte) (stadertypes.ValidatorSignature, error) {
+
+	// Build the signing request
+	request := voluntaryExitSignRequest{Type: "VOLUNTARY_EXIT"}
+	request.ForkInfo.Fork.PreviousVersion = hexutil.Encode(forkInfo.PreviousVersion)
+	request.ForkInfo.Fork.CurrentVersion = hexutil.Encode(forkInfo.CurrentVersion)
+	request.ForkInfo.Fork.Epoch = strconv.FormatUint(forkInfo.Epoch, 10)
+	request.ForkInfo.GenesisValidatorsRoot = hexutil.Encode(forkInfo.GenesisValidatorsRoot)
+	request.SigningRoot = hexutil.Encode(signingRoot[:])
+	request.VoluntaryExit.Epoch = strconv.FormatUint(epoch, 10)
+	request.VoluntaryExit.ValidatorIndex = strconv.FormatUint(validatorIndex, 10)
+
+	// Sign it
+	encodedSignature, err := ks.client.Sign(pubkey, request)
+	if err != nil {
+		return stadertypes.ValidatorSignature{}, fmt.Errorf("Web3Signer could not sign the voluntary exit for validator %s: %w", pubkey.Hex(), err)
+	}
+	signatureBytes, err := hexutil.Decode(encodedSignature)
+	if err != nil {
+		return stadertypes.ValidatorSignature{}, fmt.Errorf("Could not decode the voluntary exit signature from Web3Signer: %w", err)
+	}
+
+	// Make sure it's a valid signature from the validator key
+	blsPubkey, err := eth2types.BLSPublicKeyFromBytes(pubkey.Bytes())
+	if err != nil {
+		return stadertypes.ValidatorSignature{}, fmt.Errorf("Could not decode validator pubkey %s: %w", pubkey.Hex(), err)
+	}
+	signature, err := eth2types.BLSSignatureFromBytes(signatureBytes)
+	if err != nil {
+		return stadertypes.ValidatorSignature{}, fmt.Errorf("Could not decode the voluntary exit signature from Web3Signer: %w", err)
+	}
+	if !signature.Verify(signingRoot[:], blsPubkey) {
+		return stadertypes.ValidatorSignature{}, fmt.Errorf("Web3Signer returned an invalid voluntary exit signature for validator %s", pubkey.Hex())
+	}
+
+	return stadertypes.BytesToValidatorSignature(signatureBytes), nil
+
+}
+
+// List the validator keys imported into Web3Signer
+func (ks *Keystore) ListValidatorKeys() ([]stadertypes.ValidatorPubkey, error) {
+	keystores, err := ks.client.ListKeystores()
+	if err != nil {
+		return nil, fmt.Errorf("Could not list the Web3Signer keys: %w", err)
+	}
+	pubkeys := make([]stadertypes.ValidatorPubkey, 0, len(keystores))
+	for _, key := range keystores {
+		pubkey, err := stadertypes.HexToValidatorPubkey(hex.RemovePrefix(key.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Web3Signer listed an invalid key: %w", err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Delete a validator key from Web3Signer and its remote key definition for Lighthouse
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Drop the definition first so Lighthouse doesn't keep a key the signer no longer has
+	definitions, err := ks.loadLighthouseDefinitions()
+	if err != nil {
+		return err
+	}
+	remaining := make([]map[string]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition["voting_public_key"] != hex.AddPrefix(pubkey.Hex()) {
+			remaining = append(remaining, definition)
+		}
+	}
+	if len(remaining) != len(definitions) {
+		if err := ks.saveLighthouseDefinitions(remaining); err != nil {
+			return err
+		}
+	}
+
+	// Delete the key from the signer
+	statuses, _, err := ks.client.DeleteKeystores([]stadertypes.ValidatorPubkey{pubkey})
+	if err != nil {
+		return fmt.Errorf("Could not delete validator key %s from Web3Signer: %w", pubkey.Hex(), err)
+	}
+	switch status := statuses[0]; status.Status {
+	case keymanager.StatusDeleted, keymanager.StatusNotActive, keymanager.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("Web3Signer did not delete validator key %s: %s %s", pubkey.Hex(), status.Status, status.Message)
+	}
+
+}
+
+// Check that Web3Signer holds the key for a validator and Lighthouse has a definition for it
+func (ks *Keystore) VerifyValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	pubkeys, err := ks.ListValidatorKeys()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, signerPubkey := range pubkeys {
+		if signerPubkey == pubkey {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: Web3Signer doesn't hold validator %s", keystore.ErrValidatorKeyNotFound, pubkey.Hex())
+	}
+
+	definitions, err := ks.loadLighthouseDefinitions()
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		if definition["voting_public_key"] == hex.AddPrefix(pubkey.Hex()) {
+			if definition["enabled"] == false {
+				return fmt.Errorf("validator key %s is disabled in the Lighthouse remote key definitions", pubkey.Hex())
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("validator key %s has no Lighthouse remote key definition", pubkey.Hex())
+
+}
+
+// Add a remote key definition for Lighthouse if there isn't one yet
+func (ks *Keystore) storeLighthouseDefinition(pubkey stadertypes.ValidatorPubkey) error {
+
+	definitions, err := ks.loadLighthouseDefinitions()
+	if err != nil {
+		return err
+	}
+
+	votingPublicKey := hex.AddPrefix(pubkey.Hex())
+	for _, definition := range definitions {
+		if definition["voting_public_key"] == votingPublicKey {
+			return nil
+		}
+	}
+	definitions = append(definitions, map[string]interface{}{
+		"enabled":           true,
+		"voting_public_key": votingPublicKey,
+		"type":              "web3signer",
+		"url":               ks.url,
+	})
+	return ks.saveLighthouseDefinitions(definitions)
+
+}
+
+// Load the remote key definitions for Lighthouse; Lighthouse adds its own fields to them, so they're kept as they are
+func (ks *Keystore) loadLighthouseDefinitions() ([]map[string]interface{}, error) {
+	definitionsPath := filepath.Join(ks.keystorePath, KeystoreDir, LighthouseDir, DefinitionsFileName)
+	definitions := []map[string]interface{}{}
+	definitionsBytes, err := ioutil.ReadFile(definitionsPath)
+	if err == nil {
+		if err := yaml.Unmarshal(definitionsBytes, &definitions); err != nil {
+			return nil, fmt.Errorf("Could not decode %s: %w", definitionsPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Could not read %s: %w", definitionsPath, err)
+	}
+	return definitions, nil
+}
+
+// Write the remote key definitions for Lighthouse
+func (ks *Keystore) saveLighthouseDefinitions(definitions []map[string]interface{}) error {
+
+	definitionsPath := filepath.Join(ks.keystorePath, KeystoreDir, LighthouseDir, DefinitionsFileName)
+	definitionsBytes, err := yaml.Marshal(definitions)
+	if err != nil {
+		return fmt.Errorf("Could not encode remote key definitions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(definitionsPath), DirMode); err != nil {
+		return fmt.Errorf("Could not create the Lighthouse remote key folder: %w", err)
+	}
+	if err := ioutil.WriteFile(definitionsPath, definitionsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write %s: %w", definitionsPath, err)
+	}
+	return nil
+
+}
@@ -0,0 +1,270 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+// The key store fields the mock needs to decrypt an import
+type importedKeystore struct {
+	Crypto map[string]interface{}      `json:"crypto"`
+	Pubkey stadertypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// A Web3Signer that keeps imported keys in memory
+type mockWeb3Signer struct {
+	keys       map[string]*eth2types.BLSPrivateKey
+	corruptSig bool
+	lock       sync.Mutex
+}
+
+func (m *mockWeb3Signer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/eth/v1/keystores":
+		var request struct {
+			Keystores []string `json:"keystores"`
+			Passwords []string `json:"passwords"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var response struct {
+			Data []keymanager.Status `json:"data"`
+		}
+		for i, keystoreJson := range request.Keystores {
+			var key importedKeystore
+			status := keymanager.Status{Status: keymanager.StatusImported}
+			if err := json.Unmarshal([]byte(keystoreJson), &key); err != nil {
+				status.Status, status.Message = "error", err.Error()
+			} else if secret, err := eth2ks.New().Decrypt(key.Crypto, request.Passwords[i]); err != nil {
+				status.Status, status.Message = "error", err.Error()
+			} else if privateKey, err := eth2types.BLSPrivateKeyFromBytes(secret); err != nil {
+				status.Status, status.Message = "error", err.Error()
+			} else if _, exists := m.keys[key.Pubkey.Hex()]; exists {
+				status.Status = keymanager.StatusDuplicate
+			} else {
+				m.keys[key.Pubkey.Hex()] = privateKey
+			}
+			response.Data = append(response.Data, status)
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/eth/v1/keystores":
+		var response struct {
+			Data []keymanager.Keystore `json:"data"`
+		}
+		for pubkey := range m.keys {
+			response.Data = append(response.Data, keymanager.Keystore{ValidatingPubkey: "0x" + pubkey})
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case r.Method == http.MethodDelete && r.URL.Path == "/eth/v1/keystores":
+		var request struct {
+			Pubkeys []string `json:"pubkeys"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var response struct {
+			Data []keymanager.Status `json:"data"`
+		}
+		for _, pubkey := range request.Pubkeys {
+			status := keymanager.Status{Status: keymanager.StatusNotFound}
+			if _, exists := m.keys[strings.TrimPrefix(pubkey, "0x")]; exists {
+				delete(m.keys, strings.TrimPrefix(pubkey, "0x"))
+				status.Status = keymanager.StatusDeleted
+			}
+			response.Data = append(response.Data, status)
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/0x"):
+		key, exists := m.keys[strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/0x")]
+		if !exists {
+			http.Error(w, "Public Key not found", http.StatusNotFound)
+			return
+		}
+		var request voluntaryExitSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Type != "VOLUNTARY_EXIT" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		signingRoot, err := hexutil.Decode(request.SigningRoot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if m.corruptSig {
+			signingRoot[0] ^= 0xff
+		}
+		json.NewEncoder(w).Encode(struct {
+			Signature string `json:"signature"`
+		}{Signature: hexutil.Encode(key.Sign(signingRoot).Marshal())})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestKeystore(t *testing.T) (*Keystore, *mockWeb3Signer) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	signer := &mockWeb3Signer{keys: map[string]*eth2types.BLSPrivateKey{}}
+	server := httptest.NewServer(signer)
+	t.Cleanup(server.Close)
+	return NewKeystore(t.TempDir(), server.URL), signer
+}
+
+func TestStoreValidatorKey(t *testing.T) {
+	ks, signer := newTestKeystore(t)
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Storing the same key twice is fine and only defines it once for Lighthouse
+	for i := 0; i < 2; i++ {
+		if err := ks.StoreValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, exists := signer.keys[pubkey.Hex()]; !exists {
+		t.Fatal("key was not imported into the signer")
+	}
+
+	definitionsBytes, err := ioutil.ReadFile(filepath.Join(ks.GetKeystoreDir(), LighthouseDir, DefinitionsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var definitions []map[string]interface{}
+	if err := yaml.Unmarshal(definitionsBytes, &definitions); err != nil {
+		t.Fatal(err)
+	}
+	if len(definitions) != 1 {
+		t.Fatalf("expected 1 Lighthouse definition, got %d", len(definitions))
+	}
+	if definitions[0]["voting_public_key"] != "0x"+pubkey.Hex() || definitions[0]["type"] != "web3signer" || definitions[0]["url"] != ks.url {
+		t.Fatalf("unexpected Lighthouse definition %v", definitions[0])
+	}
+}
+
+func TestSignVoluntaryExit(t *testing.T) {
+	ks, signer := newTestKeystore(t)
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	if err := ks.StoreValidatorKey(key, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	signingRoot := [32]byte{1, 2, 3}
+	forkInfo := ForkInfo{
+		PreviousVersion:       []byte{3, 0, 0, 0},
+		CurrentVersion:        []byte{3, 0, 0, 0},
+		GenesisValidatorsRoot: make([]byte, 32),
+	}
+	signature, err := ks.SignVoluntaryExit(pubkey, forkInfo, 100, 7, signingRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature != stadertypes.BytesToValidatorSignature(key.Sign(signingRoot[:]).Marshal()) {
+		t.Fatal("signature doesn't match the one made with the local key")
+	}
+
+	// A signature over anything else is rejected
+	signer.corruptSig = true
+	if _, err := ks.SignVoluntaryExit(pubkey, forkInfo, 100, 7, signingRoot); err == nil {
+		t.Fatal("expected an invalid signature to be rejected")
+	}
+
+	// So is a key the signer doesn't have
+	otherKey, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.SignVoluntaryExit(stadertypes.BytesToValidatorPubkey(otherKey.PublicKey().Marshal()), forkInfo, 100, 7, signingRoot); err == nil {
+		t.Fatal("expected signing with an unknown key to fail")
+	}
+}
+
+func TestListDeleteAndVerifyValidatorKeys(t *testing.T) {
+	ks, _ := newTestKeystore(t)
+	keys := make([]*eth2types.BLSPrivateKey, 2)
+	for i := range keys {
+		key, err := eth2types.GenerateBLSPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.StoreValidatorKey(key, ""); err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+	}
+	kept := stadertypes.BytesToValidatorPubkey(keys[0].PublicKey().Marshal())
+	deleted := stadertypes.BytesToValidatorPubkey(keys[1].PublicKey().Marshal())
+
+	pubkeys, err := ks.ListValidatorKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(pubkeys))
+	}
+	if err := ks.VerifyValidatorKey(deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting a key leaves the other one, and deleting it again is fine
+	for i := 0; i < 2; i++ {
+		if err := ks.DeleteValidatorKey(deleted); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pubkeys, err = ks.ListValidatorKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || pubkeys[0] != kept {
+		t.Fatalf("expected only %s to be left, got %v", kept.Hex(), pubkeys)
+	}
+	if err := ks.VerifyValidatorKey(kept); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.VerifyValidatorKey(deleted); !errors.Is(err, keystore.ErrValidatorKeyNotFound) {
+		t.Fatalf("expected the deleted key to be missing, got %v", err)
+	}
+
+	// Its Lighthouse definition is gone too
+	definitions, err := ks.loadLighthouseDefinitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(definitions) != 1 || definitions[0]["voting_public_key"] != "0x"+kept.Hex() {
+		t.Fatalf("unexpected Lighthouse definitions %v", definitions)
+	}
+}
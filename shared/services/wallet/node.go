@@ -30,12 +30,24 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Use the external signer's account if there is one
+	if w.externalSigner != nil {
+		return w.externalSigner.GetAccount()
+	}
+
+	// Use the watch-only address if there's no node wallet
+	if w.IsWatchOnly() {
+		return accounts.Account{Address: *w.watchOnlyAddress}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -68,6 +80,23 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+	// Use the external signer if there is one
+	if w.externalSigner != nil {
+		transactor, err := w.getExternalSignerTransactor()
+		if err != nil {
+			return nil, err
+		}
+		return w.finishNodeAccountTransactor(transactor), nil
+	}
+
+	// A watch-only account can only export transactions to be signed elsewhere
+	if w.IsWatchOnly() {
+		if !w.offlineExport {
+			return nil, ErrWatchOnly
+		}
+		return w.finishNodeAccountTransactor(&bind.TransactOpts{From: *w.watchOnlyAddress}), nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -81,15 +110,65 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 	// Create & return transactor
 	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	if err != nil {
+		return nil, err
+	}
+	return w.finishNodeAccountTransactor(transactor), nil
+
+}
+
+// Create a transactor that signs with the external signer
+func (w *Wallet) getExternalSignerTransactor() (*bind.TransactOpts, error) {
+	account, err := w.externalSigner.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.externalSigner.SignTransaction(tx, w.chainID)
+		},
+	}, nil
+}
+
+// Apply the node's transaction settings to a transactor
+func (w *Wallet) finishNodeAccountTransactor(transactor *bind.TransactOpts) *bind.TransactOpts {
+	if w.offlineExport {
+		transactor.Signer = w.exportSigner
+		transactor.NoSend = true
+	} else {
+		transactor.Signer = w.wrapNodeSigner(transactor.Signer)
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	return transactor
 
 }
 
+// Apply the transaction policy, record and journal to a node account signer
+func (w *Wallet) wrapNodeSigner(signer bind.SignerFn) bind.SignerFn {
+	if w.txPolicy != nil {
+		signer = w.txPolicy.wrapSigner(signer)
+	}
+	if w.txRecord != nil {
+		signer = w.txRecord.wrapSigner(signer)
+	}
+	if w.txJournal != nil {
+		signer = w.txJournal.wrapSigner(signer)
+	}
+	return signer
+}
+
 func (w *Wallet) GetNodePrivateKey() (*ecdsa.PrivateKey, error) {
+	if w.externalSigner != nil {
+		return nil, ErrExternalSignerKey
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -106,6 +185,9 @@ func (w *Wallet) GetNodePrivateKey() (*ecdsa.PrivateKey, error) {
 
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
+	if w.externalSigner != nil {
+		return nil, ErrExternalSignerKey
+	}
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
@@ -191,6 +273,15 @@ func (w *Wallet) getNodeDerivedKey(index uint) (*hdkeychain.ExtendedKey, string,
 // Get the node hex encoding public key
 func (w *Wallet) GetNodePubkey() (string, error) {
 
+	// Recover it from the external signer if there is one
+	if w.externalSigner != nil {
+		pubkey, err := w.externalSigner.GetPubkey()
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(crypto.FromECDSAPub(pubkey)), nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return "", errors.New("Wallet is not initialized")
@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A node account transaction moved between machines for offline signing.
+// It's exported with only UnsignedTx set; signing it fills in SignedTx.
+type OfflineTransaction struct {
+	ChainID    *big.Int       `json:"chainId"`
+	From       common.Address `json:"from"`
+	Action     string         `json:"action"`
+	UnsignedTx hexutil.Bytes  `json:"unsignedTx"`
+	SignedTx   hexutil.Bytes  `json:"signedTx,omitempty"`
+}
+
+// Load a transaction file written for offline signing
+func LoadOfflineTransaction(path string) (*OfflineTransaction, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read %s: %w", path, err)
+	}
+	var offlineTx OfflineTransaction
+	if err := json.Unmarshal(bytes, &offlineTx); err != nil {
+		return nil, fmt.Errorf("Could not decode %s: %w", path, err)
+	}
+	return &offlineTx, nil
+}
+
+// Write the transaction to a file for moving it between machines
+func (t *OfflineTransaction) Save(path string) error {
+	bytes, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not encode transaction: %w", err)
+	}
+	if err := ioutil.WriteFile(path, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write %s: %w", path, err)
+	}
+	return nil
+}
+
+// The node account can't sign here because the node wallet is on another machine
+var ErrWatchOnly = errors.New("The node wallet isn't on this machine; node account transactions can only be exported with --offline-export and broadcast with 'stader-cli node broadcast'")
+
+// Set the node account address to use when the node wallet isn't on this machine
+func (w *Wallet) SetWatchOnlyAddress(address common.Address) {
+	w.watchOnlyAddress = &address
+}
+
+// Check if the node account is only known by its address, because there's no node wallet or external signer here
+func (w *Wallet) IsWatchOnly() bool {
+	return w.watchOnlyAddress != nil && w.externalSigner == nil && !w.IsInitialized()
+}
+
+// Export node account transactions unsigned instead of signing and sending them
+func (w *Wallet) SetOfflineExport(offlineExport bool) {
+	w.offlineExport = offlineExport
+}
+
+// Check if node account transactions are being exported for offline signing
+func (w *Wallet) IsOfflineExport() bool {
+	return w.offlineExport
+}
+
+// Get the last transaction exported for offline signing
+func (w *Wallet) GetExportedTransaction(action string) (*OfflineTransaction, error) {
+	if w.exportedTx == nil {
+		return nil, errors.New("No transaction was exported")
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx, err := w.exportedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode transaction: %w", err)
+	}
+	return &OfflineTransaction{
+		ChainID:    w.GetChainID(),
+		From:       nodeAccount.Address,
+		Action:     action,
+		UnsignedTx: unsignedTx,
+	}, nil
+}
+
+// Sign a transaction exported from another machine for the given account with the node account key.
+// The transaction policy isn't applied here; it's applied on the machine that broadcasts it.
+func (w *Wallet) SignNodeTransaction(tx *types.Transaction, from common.Address) (*types.Transaction, error) {
+	if tx.ChainId().Cmp(w.chainID) != 0 {
+		return nil, fmt.Errorf("Transaction is for chain %s but the wallet is for chain %s", tx.ChainId(), w.chainID)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if nodeAccount.Address != from {
+		return nil, fmt.Errorf("Transaction was exported for %s but the wallet's node account is %s", from.Hex(), nodeAccount.Address.Hex())
+	}
+	if w.externalSigner != nil {
+		return w.externalSigner.SignTransaction(tx, w.chainID)
+	}
+	privateKey, err := w.GetNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(w.chainID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Could not sign transaction: %w", err)
+	}
+	return signedTx, nil
+}
+
+// Apply the transaction policy to a node account transaction signed on another machine and record it, as if it had been signed here
+func (w *Wallet) RecordSignedTransaction(tx *types.Transaction) error {
+	if tx.ChainId().Cmp(w.chainID) != 0 {
+		return fmt.Errorf("Transaction is for chain %s but the wallet is for chain %s", tx.ChainId(), w.chainID)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(w.chainID), tx)
+	if err != nil {
+		return fmt.Errorf("Could not get transaction sender: %w", err)
+	}
+	if sender != nodeAccount.Address {
+		return fmt.Errorf("Transaction was signed by %s, not the node account %s", sender.Hex(), nodeAccount.Address.Hex())
+	}
+
+	// The transaction is already signed, so the signer at the end of the chain just passes it through
+	signer := w.wrapNodeSigner(func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	})
+	_, err = signer(sender, tx)
+	return err
+}
+
+// A signer that keeps the transaction unsigned for export
+func (w *Wallet) exportSigner(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	w.exportedTx = tx
+	return tx, nil
+}
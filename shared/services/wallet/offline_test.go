@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+)
+
+// The online machine has only the node address: it exports, the offline machine signs, and the online one records the signed transaction
+func TestWatchOnlyExportAndBroadcast(t *testing.T) {
+	key, err := crypto.HexToECDSA(testSignerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	w, err := NewWallet(filepath.Join(t.TempDir(), "wallet"), testChainID, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetWatchOnlyAddress(address)
+	if !w.IsWatchOnly() {
+		t.Fatal("wallet without a node wallet on disk isn't watch-only")
+	}
+
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != address {
+		t.Fatalf("got node account %s, expected %s", account.Address.Hex(), address.Hex())
+	}
+
+	// Signing here isn't possible
+	if _, err := w.GetNodeAccountTransactor(); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("got %v for a transactor without offline export", err)
+	}
+
+	// Export a transaction
+	w.SetOfflineExport(true)
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x01")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(testChainID),
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	})
+	if _, err := opts.Signer(opts.From, tx); err != nil {
+		t.Fatal(err)
+	}
+	offlineTx, err := w.GetExportedTransaction("node send")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offlineTx.From != address {
+		t.Fatalf("exported a transaction from %s", offlineTx.From.Hex())
+	}
+
+	// Sign it as the offline machine would, and record it for broadcasting
+	var exportedTx types.Transaction
+	if err := exportedTx.UnmarshalBinary(offlineTx.UnsignedTx); err != nil {
+		t.Fatal(err)
+	}
+	signedTx, err := types.SignTx(&exportedTx, types.LatestSignerForChainID(big.NewInt(testChainID)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.RecordSignedTransaction(signedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A transaction from another account is refused
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTx, err := types.SignTx(&exportedTx, types.LatestSignerForChainID(big.NewInt(testChainID)), otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.RecordSignedTransaction(otherTx); err == nil {
+		t.Fatal("recorded a transaction signed by another account")
+	}
+}
+
+// The offline machine only signs transactions exported for its own node account
+func TestSignNodeTransaction(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), testChainID, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := common.HexToAddress("0x01")
+	newTx := func(chainID int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(chainID),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			Gas:       21000,
+			To:        &to,
+		})
+	}
+
+	signedTx, err := w.SignNodeTransaction(newTx(testChainID), account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), signedTx)
+	if err != nil || sender != account.Address {
+		t.Fatalf("transaction was signed by %s (%v), expected %s", sender.Hex(), err, account.Address.Hex())
+	}
+
+	if _, err := w.SignNodeTransaction(newTx(testChainID), common.HexToAddress("0x02")); err == nil {
+		t.Fatal("signed a transaction exported for another account")
+	}
+	if _, err := w.SignNodeTransaction(newTx(testChainID+1), account.Address); err == nil {
+		t.Fatal("signed a transaction for another chain")
+	}
+}
@@ -0,0 +1,232 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Journal line types
+const (
+	journalEntrySent    string = "sent"
+	journalEntryReceipt string = "receipt"
+	journalEntryDropped string = "dropped"
+)
+
+// An append-only log of the transactions sent by the node account and their costs
+type TxJournal struct {
+	path   string
+	action string
+	lock   sync.Mutex
+}
+
+// A single line of the journal. A transaction has a "sent" line when it's signed, since the signer can't see whether the broadcast works,
+// then either a "receipt" line once it's mined or a "dropped" line once another transaction with its nonce is mined instead.
+type journalLine struct {
+	Type                 string          `json:"type"`
+	Time                 time.Time       `json:"time"`
+	Hash                 common.Hash     `json:"hash"`
+	Action               string          `json:"action,omitempty"`
+	To                   *common.Address `json:"to,omitempty"`
+	ValueWei             *big.Int        `json:"valueWei,omitempty"`
+	Nonce                uint64          `json:"nonce,omitempty"`
+	GasLimit             uint64          `json:"gasLimit,omitempty"`
+	MaxFeeWei            *big.Int        `json:"maxFeeWei,omitempty"`
+	GasUsed              uint64          `json:"gasUsed,omitempty"`
+	EffectiveGasPriceWei *big.Int        `json:"effectiveGasPriceWei,omitempty"`
+	BlockNumber          uint64          `json:"blockNumber,omitempty"`
+	Succeeded            bool            `json:"succeeded,omitempty"`
+}
+
+// A transaction from the journal, combined with its receipt if it has been mined
+type TxJournalEntry struct {
+	Hash                 common.Hash     `json:"hash"`
+	Action               string          `json:"action"`
+	To                   *common.Address `json:"to"`
+	ValueWei             *big.Int        `json:"valueWei"`
+	Nonce                uint64          `json:"nonce"`
+	SentAt               time.Time       `json:"sentAt"`
+	GasLimit             uint64          `json:"gasLimit"`
+	MaxFeeWei            *big.Int        `json:"maxFeeWei"`
+	Mined                bool            `json:"mined"`
+	Dropped              bool            `json:"dropped"`
+	MinedAt              time.Time       `json:"minedAt"`
+	BlockNumber          uint64          `json:"blockNumber"`
+	Succeeded            bool            `json:"succeeded"`
+	GasUsed              uint64          `json:"gasUsed"`
+	EffectiveGasPriceWei *big.Int        `json:"effectiveGasPriceWei"`
+	FeeWei               *big.Int        `json:"feeWei"`
+}
+
+// Create a new transaction journal; transactions signed through it are logged under the given action
+func NewTxJournal(path string, action string) *TxJournal {
+	return &TxJournal{
+		path:   path,
+		action: action,
+	}
+}
+
+// Set the journal of transactions sent by the node account
+func (w *Wallet) SetTxJournal(journal *TxJournal) {
+	w.txJournal = journal
+}
+
+// Log the receipt of a mined transaction and the gas price it paid
+func (w *Wallet) RecordTxReceipt(receipt *types.Receipt, effectiveGasPrice *big.Int) error {
+	if w.txJournal == nil {
+		return nil
+	}
+	line := journalLine{
+		Type:                 journalEntryReceipt,
+		Time:                 time.Now(),
+		Hash:                 receipt.TxHash,
+		GasUsed:              receipt.GasUsed,
+		EffectiveGasPriceWei: effectiveGasPrice,
+		Succeeded:            receipt.Status == types.ReceiptStatusSuccessful,
+	}
+	if receipt.BlockNumber != nil {
+		line.BlockNumber = receipt.BlockNumber.Uint64()
+	}
+	return w.txJournal.append(line)
+}
+
+// Log a transaction that will never be mined because another transaction with its nonce was, either because it was replaced or because it was never broadcast
+func (w *Wallet) RecordTxDropped(hash common.Hash) error {
+	if w.txJournal == nil {
+		return nil
+	}
+	return w.txJournal.append(journalLine{
+		Type: journalEntryDropped,
+		Time: time.Now(),
+		Hash: hash,
+	})
+}
+
+// Get every transaction in the journal, oldest first
+func (w *Wallet) GetTxJournal() ([]TxJournalEntry, error) {
+	if w.txJournal == nil {
+		return []TxJournalEntry{}, nil
+	}
+	return w.txJournal.read()
+}
+
+// Wrap a transaction signer so it logs every transaction it signs
+func (j *TxJournal) wrapSigner(signer bind.SignerFn) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		err = j.append(journalLine{
+			Type:      journalEntrySent,
+			Time:      time.Now(),
+			Hash:      signedTx.Hash(),
+			Action:    j.action,
+			To:        signedTx.To(),
+			ValueWei:  signedTx.Value(),
+			Nonce:     signedTx.Nonce(),
+			GasLimit:  signedTx.Gas(),
+			MaxFeeWei: signedTx.GasFeeCap(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+	}
+}
+
+// Add a line to the end of the journal
+func (j *TxJournal) append(line journalLine) error {
+	bytes, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("Could not encode transaction journal entry: %w", err)
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("Could not create transaction journal folder: %w", err)
+	}
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FileMode)
+	if err != nil {
+		return fmt.Errorf("Could not open transaction journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(bytes, '\n')); err != nil {
+		return fmt.Errorf("Could not write transaction journal: %w", err)
+	}
+	return nil
+}
+
+// Read the journal and combine each transaction with its receipt
+func (j *TxJournal) read() ([]TxJournalEntry, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []TxJournalEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not open transaction journal: %w", err)
+	}
+	defer file.Close()
+
+	entries := []TxJournalEntry{}
+	indices := map[common.Hash]int{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line journalLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			// Skip a line left incomplete by a crash
+			continue
+		}
+		switch line.Type {
+		case journalEntrySent:
+			indices[line.Hash] = len(entries)
+			entries = append(entries, TxJournalEntry{
+				Hash:      line.Hash,
+				Action:    line.Action,
+				To:        line.To,
+				ValueWei:  line.ValueWei,
+				Nonce:     line.Nonce,
+				SentAt:    line.Time,
+				GasLimit:  line.GasLimit,
+				MaxFeeWei: line.MaxFeeWei,
+			})
+		case journalEntryReceipt:
+			index, exists := indices[line.Hash]
+			if !exists {
+				continue
+			}
+			entry := &entries[index]
+			entry.Mined = true
+			entry.MinedAt = line.Time
+			entry.BlockNumber = line.BlockNumber
+			entry.Succeeded = line.Succeeded
+			entry.GasUsed = line.GasUsed
+			entry.EffectiveGasPriceWei = line.EffectiveGasPriceWei
+			if line.EffectiveGasPriceWei != nil {
+				entry.FeeWei = new(big.Int).Mul(line.EffectiveGasPriceWei, new(big.Int).SetUint64(line.GasUsed))
+			}
+		case journalEntryDropped:
+			if index, exists := indices[line.Hash]; exists {
+				entries[index].Dropped = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Could not read transaction journal: %w", err)
+	}
+	return entries, nil
+}
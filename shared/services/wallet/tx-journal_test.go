@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Every signed transaction is journalled, then marked as mined or dropped
+func TestTxJournal(t *testing.T) {
+	w := &Wallet{}
+	w.SetTxJournal(NewTxJournal(filepath.Join(t.TempDir(), "tx-journal"), "node send"))
+	signer := w.txJournal.wrapSigner(func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	})
+
+	to := common.HexToAddress("0x01")
+	hashes := []common.Hash{}
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx, err := signer(common.Address{}, types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasFeeCap: big.NewInt(2),
+			Gas:       50000,
+			To:        &to,
+			Value:     big.NewInt(5),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, tx.Hash())
+	}
+
+	receipt := &types.Receipt{TxHash: hashes[0], Status: types.ReceiptStatusSuccessful, GasUsed: 21000, BlockNumber: big.NewInt(10)}
+	if err := w.RecordTxReceipt(receipt, big.NewInt(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.RecordTxDropped(hashes[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := w.GetTxJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, expected 3", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Hash != hashes[i] || entry.Nonce != uint64(i) || entry.Action != "node send" || entry.GasLimit != 50000 {
+			t.Fatalf("entry %d doesn't match the signed transaction: %+v", i, entry)
+		}
+	}
+	if !entries[0].Mined || !entries[0].Succeeded || entries[0].FeeWei.Cmp(big.NewInt(63000)) != 0 {
+		t.Fatalf("expected the first transaction to be mined for 63000 wei, got %+v", entries[0])
+	}
+	if entries[1].Mined || !entries[1].Dropped {
+		t.Fatalf("expected the second transaction to be dropped, got %+v", entries[1])
+	}
+	if entries[2].Mined || entries[2].Dropped {
+		t.Fatalf("expected the third transaction to still be pending, got %+v", entries[2])
+	}
+}
@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+// The window the gas budget applies to
+const gasBudgetWindow time.Duration = 24 * time.Hour
+
+// Limits the fees the node account can commit to
+type TxPolicy struct {
+	// The max fee of a single transaction; nil or 0 means no limit
+	maxTxFee *big.Int
+	// The max total fee of the transactions signed during the last 24 hours; nil or 0 means no limit
+	dailyBudget *big.Int
+	// Where the fees of recent transactions are recorded
+	spendPath string
+	lock      sync.Mutex
+}
+
+// A transaction counted against the gas budget
+type gasSpend struct {
+	Time   time.Time   `json:"time"`
+	Hash   common.Hash `json:"hash"`
+	FeeWei *big.Int    `json:"feeWei"`
+}
+
+// Create a new transaction policy
+func NewTxPolicy(maxTxFee *big.Int, dailyBudget *big.Int, spendPath string) *TxPolicy {
+	return &TxPolicy{
+		maxTxFee:    maxTxFee,
+		dailyBudget: dailyBudget,
+		spendPath:   spendPath,
+	}
+}
+
+// Set the policy applied to every node account transactor
+func (w *Wallet) SetTxPolicy(policy *TxPolicy) {
+	w.txPolicy = policy
+}
+
+// Get the total fees committed to during the last 24 hours
+func (p *TxPolicy) GetDailySpend() (*big.Int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	spends, err := p.loadSpends()
+	if err != nil {
+		return nil, err
+	}
+	return sumSpends(spends), nil
+}
+
+// Wrap a transaction signer so it refuses transactions that would break the policy, and records the ones it signs
+func (p *TxPolicy) wrapSigner(signer bind.SignerFn) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+
+		// The worst case fee is the max fee for every unit of gas
+		fee := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+		if p.maxTxFee != nil && p.maxTxFee.Sign() > 0 && fee.Cmp(p.maxTxFee) > 0 {
+			return nil, fmt.Errorf("this transaction could cost up to %.6f ETH, which is above the per-transaction fee cap of %.6f ETH (txFeeCap); lower the max fee or gas limit, or raise the cap", eth.WeiToEth(fee), eth.WeiToEth(p.maxTxFee))
+		}
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		// Check the rolling budget
+		spends, err := p.loadSpends()
+		if err != nil {
+			return nil, err
+		}
+		if p.dailyBudget != nil && p.dailyBudget.Sign() > 0 {
+			spent := sumSpends(spends)
+			if new(big.Int).Add(spent, fee).Cmp(p.dailyBudget) > 0 {
+				return nil, fmt.Errorf("this transaction could cost up to %.6f ETH, but %.6f ETH of the daily gas budget of %.6f ETH (dailyGasBudget) has already been used in the last 24 hours", eth.WeiToEth(fee), eth.WeiToEth(spent), eth.WeiToEth(p.dailyBudget))
+			}
+		}
+
+		// Sign and record it; a transaction that ends up not being sent still counts, erring on the side of caution
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		spends = append(spends, gasSpend{
+			Time:   time.Now(),
+			Hash:   signedTx.Hash(),
+			FeeWei: fee,
+		})
+		if err := p.saveSpends(spends); err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+
+	}
+}
+
+// Load the transactions from the last 24 hours
+func (p *TxPolicy) loadSpends() ([]gasSpend, error) {
+	bytes, err := ioutil.ReadFile(p.spendPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []gasSpend{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read gas spend record: %w", err)
+	}
+	var spends []gasSpend
+	if err := json.Unmarshal(bytes, &spends); err != nil {
+		return nil, fmt.Errorf("Could not decode gas spend record: %w", err)
+	}
+
+	cutoff := time.Now().Add(-gasBudgetWindow)
+	recent := []gasSpend{}
+	for _, spend := range spends {
+		if spend.Time.After(cutoff) && spend.FeeWei != nil {
+			recent = append(recent, spend)
+		}
+	}
+	return recent, nil
+}
+
+// Save the record, replacing the file atomically so a crash can't corrupt it
+func (p *TxPolicy) saveSpends(spends []gasSpend) error {
+	bytes, err := json.Marshal(spends)
+	if err != nil {
+		return fmt.Errorf("Could not encode gas spend record: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.spendPath), 0700); err != nil {
+		return fmt.Errorf("Could not create gas spend record folder: %w", err)
+	}
+	if err := writeFileAtomic(p.spendPath, bytes); err != nil {
+		return fmt.Errorf("Could not write gas spend record: %w", err)
+	}
+	return nil
+}
+
+func sumSpends(spends []gasSpend) *big.Int {
+	total := big.NewInt(0)
+	for _, spend := range spends {
+		total.Add(total, spend.FeeWei)
+	}
+	return total
+}
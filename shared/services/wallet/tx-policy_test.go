@@ -0,0 +1,149 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A transaction whose worst case fee is the given number of wei
+func newTestPolicyTx(nonce uint64, feeWei int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasFeeCap: big.NewInt(feeWei / 1000),
+		Gas:       1000,
+	})
+}
+
+func signWithPolicy(policy *TxPolicy, tx *types.Transaction) error {
+	signer := policy.wrapSigner(func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	})
+	_, err := signer(common.Address{}, tx)
+	return err
+}
+
+func TestTxPolicyFeeCap(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		maxTxFee *big.Int
+		feeWei   int64
+		allowed  bool
+	}{
+		{"no cap", nil, 5000000, true},
+		{"zero means no cap", big.NewInt(0), 5000000, true},
+		{"below the cap", big.NewInt(2000000), 1000000, true},
+		{"at the cap", big.NewInt(2000000), 2000000, true},
+		{"above the cap", big.NewInt(2000000), 3000000, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewTxPolicy(test.maxTxFee, nil, filepath.Join(t.TempDir(), "gas-spend.json"))
+			err := signWithPolicy(policy, newTestPolicyTx(0, test.feeWei))
+			if (err == nil) != test.allowed {
+				t.Fatalf("got %v, expected allowed to be %t", err, test.allowed)
+			}
+		})
+	}
+
+}
+
+func TestTxPolicyDailyBudget(t *testing.T) {
+
+	type spend struct {
+		age    time.Duration
+		feeWei int64
+	}
+	tests := []struct {
+		name          string
+		budget        *big.Int
+		spends        []spend
+		feeWei        int64
+		allowed       bool
+		expectedSpend int64
+	}{
+		{"no budget", nil, []spend{{time.Hour, 9000000}}, 5000000, true, 14000000},
+		{"within the budget", big.NewInt(10000000), []spend{{time.Hour, 4000000}}, 5000000, true, 9000000},
+		{"exactly the budget", big.NewInt(10000000), []spend{{time.Hour, 5000000}}, 5000000, true, 10000000},
+		{"over the budget", big.NewInt(10000000), []spend{{time.Hour, 6000000}}, 5000000, false, 6000000},
+		{"old spends roll off", big.NewInt(10000000), []spend{{25 * time.Hour, 9000000}, {time.Hour, 1000000}}, 5000000, true, 6000000},
+		{"spends just inside the window still count", big.NewInt(10000000), []spend{{23 * time.Hour, 9000000}}, 5000000, false, 9000000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spendPath := filepath.Join(t.TempDir(), "gas-spend.json")
+			spends := []gasSpend{}
+			for i, existing := range test.spends {
+				spends = append(spends, gasSpend{
+					Time:   time.Now().Add(-existing.age),
+					Hash:   common.BigToHash(big.NewInt(int64(i + 1))),
+					FeeWei: big.NewInt(existing.feeWei),
+				})
+			}
+			policy := NewTxPolicy(nil, test.budget, spendPath)
+			if err := policy.saveSpends(spends); err != nil {
+				t.Fatal(err)
+			}
+
+			err := signWithPolicy(policy, newTestPolicyTx(0, test.feeWei))
+			if (err == nil) != test.allowed {
+				t.Fatalf("got %v, expected allowed to be %t", err, test.allowed)
+			}
+			spent, err := policy.GetDailySpend()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spent.Cmp(big.NewInt(test.expectedSpend)) != 0 {
+				t.Fatalf("got a daily spend of %s wei, expected %d", spent, test.expectedSpend)
+			}
+		})
+	}
+
+}
+
+func TestTxPolicySpendsFile(t *testing.T) {
+	spendPath := filepath.Join(t.TempDir(), "data", "gas-spend.json")
+	policy := NewTxPolicy(nil, big.NewInt(10000000), spendPath)
+
+	// A missing file is an empty record, and the folder is created on the first save
+	if spent, err := policy.GetDailySpend(); err != nil || spent.Sign() != 0 {
+		t.Fatalf("expected no spend without a record, got %v (%v)", spent, err)
+	}
+	if err := policy.saveSpends([]gasSpend{{Time: time.Now().Add(-48 * time.Hour), FeeWei: big.NewInt(1)}}); err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestPolicyTx(1, 2000000)
+	if err := signWithPolicy(policy, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing prunes the expired spend and records the new one
+	bytes, err := ioutil.ReadFile(spendPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []gasSpend
+	if err := json.Unmarshal(bytes, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 || saved[0].Hash != tx.Hash() || saved[0].FeeWei.Cmp(big.NewInt(2000000)) != 0 {
+		t.Fatalf("unexpected gas spend record %+v", saved)
+	}
+
+	// A corrupted record stops signing instead of resetting the budget
+	if err := ioutil.WriteFile(spendPath, []byte("{"), FileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := signWithPolicy(policy, newTestPolicyTx(2, 1000000)); err == nil {
+		t.Fatal("expected a corrupted gas spend record to stop signing")
+	}
+}
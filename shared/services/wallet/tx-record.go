@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The number of transactions kept in the record
+const maxRecordedTransactions int = 100
+
+// Keeps the most recent transactions signed by the node account so they can be tracked and replaced
+type TxRecord struct {
+	path string
+	lock sync.Mutex
+}
+
+// A transaction signed by the node account
+type SignedTransaction struct {
+	Hash     common.Hash   `json:"hash"`
+	SignedAt time.Time     `json:"signedAt"`
+	RawTx    hexutil.Bytes `json:"rawTx"`
+}
+
+// Create a new transaction record
+func NewTxRecord(path string) *TxRecord {
+	return &TxRecord{
+		path: path,
+	}
+}
+
+// Set the record of transactions signed by the node account
+func (w *Wallet) SetTxRecord(record *TxRecord) {
+	w.txRecord = record
+}
+
+// Get the transactions signed by the node account, oldest first
+func (w *Wallet) GetSignedTransactions() ([]SignedTransaction, error) {
+	if w.txRecord == nil {
+		return []SignedTransaction{}, nil
+	}
+	w.txRecord.lock.Lock()
+	defer w.txRecord.lock.Unlock()
+	return w.txRecord.load()
+}
+
+// Decode the signed transaction
+func (t SignedTransaction) Decode() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(t.RawTx); err != nil {
+		return nil, fmt.Errorf("Could not decode transaction %s: %w", t.Hash.Hex(), err)
+	}
+	return tx, nil
+}
+
+// Wrap a transaction signer so it records every transaction it signs
+func (r *TxRecord) wrapSigner(signer bind.SignerFn) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		rawTx, err := signedTx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode transaction: %w", err)
+		}
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		transactions, err := r.load()
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, SignedTransaction{
+			Hash:     signedTx.Hash(),
+			SignedAt: time.Now(),
+			RawTx:    rawTx,
+		})
+		if len(transactions) > maxRecordedTransactions {
+			transactions = transactions[len(transactions)-maxRecordedTransactions:]
+		}
+		if err := r.save(transactions); err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+	}
+}
+
+func (r *TxRecord) load() ([]SignedTransaction, error) {
+	bytes, err := ioutil.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []SignedTransaction{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read transaction record: %w", err)
+	}
+	var transactions []SignedTransaction
+	if err := json.Unmarshal(bytes, &transactions); err != nil {
+		return nil, fmt.Errorf("Could not decode transaction record: %w", err)
+	}
+	return transactions, nil
+}
+
+// Save the record, replacing the file atomically so a crash can't corrupt it
+func (r *TxRecord) save(transactions []SignedTransaction) error {
+	bytes, err := json.Marshal(transactions)
+	if err != nil {
+		return fmt.Errorf("Could not encode transaction record: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0700); err != nil {
+		return fmt.Errorf("Could not create transaction record folder: %w", err)
+	}
+	if err := writeFileAtomic(r.path, bytes); err != nil {
+		return fmt.Errorf("Could not write transaction record: %w", err)
+	}
+	return nil
+}
@@ -24,8 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -59,6 +61,23 @@ func (w *Wallet) GetValidatorKeyCount() (uint, error) {
 
 }
 
+// Move the wallet index the next validator key is derived from forward, such as past keys registered without this wallet.
+// It can't move back, since that would derive keys that are already in use again.
+func (w *Wallet) SetNextValidatorKeyIndex(index uint) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+
+	if index < w.ws.NextAccount {
+		return fmt.Errorf("the next validator key index is already %d and can't be moved back to %d", w.ws.NextAccount, index)
+	}
+	w.ws.NextAccount = index
+	return nil
+
+}
+
 // Get a validator key by index
 func (w *Wallet) GetValidatorKeyAt(index uint) (*eth2types.BLSPrivateKey, error) {
 
@@ -73,7 +92,7 @@ func (w *Wallet) GetValidatorKeyAt(index uint) (*eth2types.BLSPrivateKey, error)
 
 }
 
-// Get a validator key by public key
+// Get a validator key by public key, from the derived keys or else the imported ones
 func (w *Wallet) GetValidatorKeyByPubkey(pubkey stadertypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
 	// Check wallet is initialized
@@ -105,9 +124,15 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey stadertypes.ValidatorPubkey) (*e
 		}
 	}
 
-	// Check validator key
+	// Fall back to the imported keys
 	if validatorKey == nil {
-		return nil, fmt.Errorf("Validator %s key not found", pubkeyHex)
+		importedKey, err := w.GetImportedValidatorKey(pubkey)
+		if errors.Is(err, keystore.ErrValidatorKeyNotFound) {
+			return nil, fmt.Errorf("Validator %s key not found", pubkeyHex)
+		} else if err != nil {
+			return nil, err
+		}
+		return importedKey.PrivateKey, nil
 	}
 
 	// Cache validator key index
@@ -192,6 +217,47 @@ func (w *Wallet) DeleteValidatorStores() error {
 
 }
 
+// Deletes a validator key from every keystore, leaving the other validators in place
+func (w *Wallet) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+
+	for name := range w.keystores {
+		if err := w.keystores[name].DeleteValidatorKey(pubkey); err != nil {
+			return fmt.Errorf("Could not delete %s validator key: %w", name, err)
+		}
+	}
+
+	return nil
+
+}
+
+// Checks a validator key in every keystore, returning the problem with each keystore that won't load it
+func (w *Wallet) VerifyValidatorKey(pubkey types.ValidatorPubkey) map[string]error {
+
+	problems := map[string]error{}
+	for name := range w.keystores {
+		if err := w.keystores[name].VerifyValidatorKey(pubkey); err != nil {
+			problems[name] = err
+		}
+	}
+	return problems
+
+}
+
+// Lists the validator keys held by each keystore
+func (w *Wallet) ListValidatorKeys() (map[string][]types.ValidatorPubkey, error) {
+
+	keys := map[string][]types.ValidatorPubkey{}
+	for name := range w.keystores {
+		pubkeys, err := w.keystores[name].ListValidatorKeys()
+		if err != nil {
+			return nil, fmt.Errorf("Could not list %s validator keys: %w", name, err)
+		}
+		keys[name] = pubkeys
+	}
+	return keys, nil
+
+}
+
 // Returns the next validator key that will be generated without saving it
 func (w *Wallet) GetNextValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
@@ -338,24 +404,53 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey stadertypes.ValidatorPubkey, sta
 
 }
 
-// Create a new validator key
-func (w *Wallet) RebuildLodestarValidatorKeys() error {
-	keys, err := w.GetValidatorKeys(0, w.ws.NextAccount)
-	if err != nil {
-		return err
+// Rebuild a keystore from the mnemonic and the imported keys, so it holds every key up to the wallet's next index.
+// Keys the keystore already holds and can load are left as they are, so rebuilding again changes nothing, unless force is set
+// to write every key again, e.g. when the keystore's format changes. Every key written is verified.
+// Returns the keys that were written and the ones that were left as they are.
+func (w *Wallet) RebuildValidatorKeys(name string, force bool) ([]types.ValidatorPubkey, []types.ValidatorPubkey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, nil, errors.New("Wallet is not initialized")
 	}
 
-	lodestarStore, ok := w.keystores["lodestar"]
+	ks, ok := w.keystores[name]
 	if !ok {
-		return fmt.Errorf("could not find store to RebuildLodestarValidatorKeys lodestar")
+		return nil, nil, fmt.Errorf("the node wallet has no %s keystore; it has %s", name, strings.Join(w.GetKeystoreNames(), ", "))
 	}
+
+	// Get the derived and imported keys
+	keys, err := w.GetValidatorKeys(0, w.ws.NextAccount)
+	if err != nil {
+		return nil, nil, err
+	}
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	keys = append(keys, importedKeys...)
+
+	rebuilt := []types.ValidatorPubkey{}
+	unchanged := []types.ValidatorPubkey{}
 	for _, key := range keys {
-		if err := lodestarStore.StoreValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
-			return fmt.Errorf("could not store validator key %s in %s keystore: %w", key.PublicKey.Hex(), "lodestar", err)
+		if !force {
+			if err := ks.VerifyValidatorKey(key.PublicKey); err == nil {
+				unchanged = append(unchanged, key.PublicKey)
+				continue
+			}
+		}
+		if err := ks.StoreValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
+			return nil, nil, fmt.Errorf("could not store validator key %s in %s keystore: %w", key.PublicKey.Hex(), name, err)
 		}
+		if err := ks.VerifyValidatorKey(key.PublicKey); err != nil {
+			return nil, nil, fmt.Errorf("validator key %s was stored in %s keystore but can't be loaded: %w", key.PublicKey.Hex(), name, err)
+		}
+		rebuilt = append(rebuilt, key.PublicKey)
 	}
 
-	return nil
+	return rebuilt, unchanged, nil
+
 }
 
 // Get a validator private key by index
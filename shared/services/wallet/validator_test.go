@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	tkkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/teku"
+)
+
+func TestRebuildValidatorKeys(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), testChainID, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	w.ws.NextAccount = 2
+	w.SetImportedKeyStore(NewImportedKeyStore(filepath.Join(dir, "imported-keys")))
+	w.AddKeystore("teku", tkkeystore.NewKeystore(filepath.Join(dir, "validators"), pm))
+
+	key, keystoreBytes := newTestKeystore(t, "password", "")
+	importedKey, err := w.DecryptValidatorKeystore(keystoreBytes, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.importedKeys.save(importedKey.PrivateKey, importedKey.DerivationPath, w.encryptor); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := w.RebuildValidatorKeys("lighthouse", false); err == nil {
+		t.Fatal("rebuilt a keystore the wallet doesn't have")
+	}
+
+	rebuilt, unchanged, err := w.RebuildValidatorKeys("teku", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rebuilt) != 3 || len(unchanged) != 0 {
+		t.Fatalf("rebuilt %d and left %d keys on the first pass", len(rebuilt), len(unchanged))
+	}
+	if rebuilt[2] != stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()) {
+		t.Fatalf("rebuilt %s instead of the imported key", rebuilt[2].Hex())
+	}
+
+	rebuilt, unchanged, err = w.RebuildValidatorKeys("teku", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rebuilt) != 0 || len(unchanged) != 3 {
+		t.Fatalf("rebuilt %d and left %d keys on the second pass", len(rebuilt), len(unchanged))
+	}
+
+	// Forcing writes every key again
+	rebuilt, unchanged, err = w.RebuildValidatorKeys("teku", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rebuilt) != 3 || len(unchanged) != 0 {
+		t.Fatalf("rebuilt %d and left %d keys on a forced pass", len(rebuilt), len(unchanged))
+	}
+}
+
+func TestSetNextValidatorKeyIndex(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), testChainID, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetNextValidatorKeyIndex(1); err == nil {
+		t.Fatal("moved the index of an uninitialized wallet")
+	}
+	if err := w.Recover(DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	w.ws.NextAccount = 2
+
+	// Moving it back would derive keys that are already in use again
+	if err := w.SetNextValidatorKeyIndex(1); err == nil {
+		t.Fatal("moved the index back")
+	}
+	for _, index := range []uint{2, 5} {
+		if err := w.SetNextValidatorKeyIndex(index); err != nil {
+			t.Fatal(err)
+		}
+		if count, _ := w.GetValidatorKeyCount(); count != index {
+			t.Fatalf("got next index %d, expected %d", count, index)
+		}
+	}
+}
@@ -27,10 +27,12 @@ import (
 	"io/ioutil"
 	"math/big"
 	"os"
+	"sort"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
@@ -78,10 +80,32 @@ type Wallet struct {
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// Validator keys imported instead of derived from the mnemonic
+	importedKeys *ImportedKeyStore
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Fee limits applied to node account transactions
+	txPolicy *TxPolicy
+
+	// Record of the transactions signed by the node account
+	txRecord *TxRecord
+
+	// Append-only log of the transactions sent by the node account
+	txJournal *TxJournal
+
+	// Export node account transactions unsigned instead of signing and sending them
+	offlineExport bool
+	exportedTx    *types.Transaction
+
+	// External signer that holds the node account key instead of this wallet
+	externalSigner *ExternalSigner
+
+	// Node account address for a machine without the node wallet, which can only export and broadcast transactions
+	watchOnlyAddress *common.Address
 }
 
 // Encrypted wallet store
@@ -133,6 +157,22 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Get a keystore of the wallet by name
+func (w *Wallet) GetKeystore(name string) (keystore.Keystore, bool) {
+	ks, exists := w.keystores[name]
+	return ks, exists
+}
+
+// Get the names of the wallet's keystores, in order
+func (w *Wallet) GetKeystoreNames() []string {
+	names := make([]string, 0, len(w.keystores))
+	for name := range w.keystores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -294,6 +334,18 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
+	if w.externalSigner != nil {
+		tx := types.Transaction{}
+		if err := tx.UnmarshalBinary(serializedTx); err != nil {
+			return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
+		}
+		signedTx, err := w.externalSigner.SignTransaction(&tx, w.chainID)
+		if err != nil {
+			return nil, err
+		}
+		return signedTx.MarshalBinary()
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -322,6 +374,10 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	if w.externalSigner != nil {
+		return w.externalSigner.SignMessage([]byte(message))
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -428,3 +484,28 @@ func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemon
 	return nil
 
 }
+
+// Write a file by writing a temporary file next to it and renaming it over the original, so a crash leaves either the old or the new contents
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FileMode)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/stader-labs/stader-node/shared/services/wallet"
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
@@ -101,6 +102,8 @@ type CanNodeDepositResponse struct {
 	MaxValidatorLimitReached bool           `json:"maxValidatorLimitReached"`
 	InputKeyLimitReached     bool           `json:"inputKeyLimitReached"`
 	InputKeyLimit            uint16         `json:"inputKeyLimit"`
+	NotEnoughImportedKeys    bool           `json:"notEnoughImportedKeys"`
+	UnusedImportedKeys       uint64         `json:"unusedImportedKeys"`
 	GasInfo                  stader.GasInfo `json:"gasInfo"`
 }
 
@@ -118,9 +121,10 @@ type CanNodeSendResponse struct {
 	GasInfo             stader.GasInfo `json:"gasInfo"`
 }
 type NodeSendResponse struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error"`
-	TxHash common.Hash `json:"txHash"`
+	Status    string                     `json:"status"`
+	Error     string                     `json:"error"`
+	TxHash    common.Hash                `json:"txHash"`
+	OfflineTx *wallet.OfflineTransaction `json:"offlineTx,omitempty"`
 }
 
 type NodeSyncProgressResponse struct {
@@ -130,6 +134,80 @@ type NodeSyncProgressResponse struct {
 	BcStatus ClientManagerStatus `json:"bcStatus"`
 }
 
+type NodeTransaction struct {
+	Hash              common.Hash     `json:"hash"`
+	Nonce             uint64          `json:"nonce"`
+	To                *common.Address `json:"to"`
+	ValueWei          *big.Int        `json:"valueWei"`
+	MaxFeeWei         *big.Int        `json:"maxFeeWei"`
+	MaxPriorityFeeWei *big.Int        `json:"maxPriorityFeeWei"`
+	GasLimit          uint64          `json:"gasLimit"`
+	SignedAt          time.Time       `json:"signedAt"`
+	Status            string          `json:"status"`
+	BlockNumber       uint64          `json:"blockNumber"`
+}
+
+type NodeTxListResponse struct {
+	Status         string            `json:"status"`
+	Error          string            `json:"error"`
+	ConfirmedNonce uint64            `json:"confirmedNonce"`
+	PendingNonce   uint64            `json:"pendingNonce"`
+	Transactions   []NodeTransaction `json:"transactions"`
+}
+
+type CanNodeReplaceTxResponse struct {
+	Status            string          `json:"status"`
+	Error             string          `json:"error"`
+	CanReplace        bool            `json:"canReplace"`
+	NotFound          bool            `json:"notFound"`
+	AlreadyMined      bool            `json:"alreadyMined"`
+	Transaction       NodeTransaction `json:"transaction"`
+	MinMaxFeeWei      *big.Int        `json:"minMaxFeeWei"`
+	MinPriorityFeeWei *big.Int        `json:"minPriorityFeeWei"`
+	GasInfo           stader.GasInfo  `json:"gasInfo"`
+}
+
+type NodeReplaceTxResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type NodeBroadcastResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type NodeTxHistoryResponse struct {
+	Status       string                  `json:"status"`
+	Error        string                  `json:"error"`
+	Transactions []wallet.TxJournalEntry `json:"transactions"`
+}
+
+type GasPricesResponse struct {
+	Status     string           `json:"status"`
+	Error      string           `json:"error"`
+	Suggestion GasFeeSuggestion `json:"suggestion"`
+}
+
+// A max fee and the priority fee included in it
+type GasFee struct {
+	MaxFeeWei      *big.Int `json:"maxFeeWei"`
+	PriorityFeeWei *big.Int `json:"priorityFeeWei"`
+}
+
+type GasFeeSuggestion struct {
+	// The base fee of the next block
+	BaseFeeWei *big.Int `json:"baseFeeWei"`
+	// True if the base fee went up over the sampled blocks
+	BaseFeeRising bool `json:"baseFeeRising"`
+
+	Slow     GasFee `json:"slow"`
+	Standard GasFee `json:"standard"`
+	Fast     GasFee `json:"fast"`
+}
+
 type ContractsInfoResponse struct {
 	Status                     string         `json:"status"`
 	Error                      string         `json:"error"`
@@ -196,6 +274,124 @@ type ExitValidatorResponse struct {
 	Error          string `json:"error"`
 }
 
+type ValidatorDutiesResponse struct {
+	Status                 string                   `json:"status"`
+	Error                  string                   `json:"error"`
+	CurrentEpoch           uint64                   `json:"currentEpoch"`
+	EndEpoch               uint64                   `json:"endEpoch"`
+	ProposerLookaheadEpoch uint64                   `json:"proposerLookaheadEpoch"`
+	SyncLookaheadEpoch     uint64                   `json:"syncLookaheadEpoch"`
+	ActiveValidators       int                      `json:"activeValidators"`
+	ProposerDuties         []ValidatorProposerDuty  `json:"proposerDuties"`
+	SyncDuties             []ValidatorSyncDuty      `json:"syncDuties"`
+	DutyFreeWindow         *ValidatorDutyFreeWindow `json:"dutyFreeWindow"`
+}
+
+type ValidatorSettingsResponse struct {
+	Status                string              `json:"status"`
+	Error                 string              `json:"error"`
+	GraffitiTemplate      string              `json:"graffitiTemplate"`
+	GasLimit              uint64              `json:"gasLimit"`
+	KeymanagerUnavailable bool                `json:"keymanagerUnavailable"`
+	Validators            []ValidatorSettings `json:"validators"`
+}
+
+type ValidatorSettings struct {
+	Pubkey          types.ValidatorPubkey `json:"pubkey"`
+	Graffiti        string                `json:"graffiti"`
+	DesiredGraffiti string                `json:"desiredGraffiti"`
+	GasLimit        uint64                `json:"gasLimit"`
+	DesiredGasLimit uint64                `json:"desiredGasLimit"`
+	Updated         bool                  `json:"updated"`
+}
+
+// Kinds of drift between the node's validator keys
+const (
+	ValidatorKeyFinding_MissingLocally    = "missing-locally"
+	ValidatorKeyFinding_Unloadable        = "unloadable"
+	ValidatorKeyFinding_NextAccountBehind = "next-account-behind"
+	ValidatorKeyFinding_Unregistered      = "unregistered"
+	ValidatorKeyFinding_TerminalLoaded    = "terminal-loaded"
+	ValidatorKeyFinding_IndexGap          = "index-gap"
+)
+
+type ValidatorReconcileResponse struct {
+	Status                string                `json:"status"`
+	Error                 string                `json:"error"`
+	NextAccount           uint64                `json:"nextAccount"`
+	Keystore              string                `json:"keystore"`
+	KeymanagerUnavailable bool                  `json:"keymanagerUnavailable"`
+	RegisteredValidators  int                   `json:"registeredValidators"`
+	LocalValidators       int                   `json:"localValidators"`
+	LoadedValidators      int                   `json:"loadedValidators"`
+	Findings              []ValidatorKeyFinding `json:"findings"`
+}
+
+type ValidatorKeyFinding struct {
+	Kind         string                `json:"kind"`
+	Pubkey       types.ValidatorPubkey `json:"pubkey"`
+	Detail       string                `json:"detail"`
+	SuggestedFix string                `json:"suggestedFix"`
+}
+
+type RemoveValidatorKeyResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	ValidatorActive bool   `json:"validatorActive"`
+}
+
+// What happened to each keystore given to import-keystores
+const (
+	ImportedKeystore_Imported        = "imported"
+	ImportedKeystore_AlreadyImported = "already-imported"
+	ImportedKeystore_InUse           = "in-use"
+)
+
+type ImportKeystoresResponse struct {
+	Status    string             `json:"status"`
+	Error     string             `json:"error"`
+	Keystores []ImportedKeystore `json:"keystores"`
+}
+
+type ImportedKeystore struct {
+	File   string                `json:"file"`
+	Pubkey types.ValidatorPubkey `json:"pubkey"`
+	Result string                `json:"result"`
+	Detail string                `json:"detail"`
+}
+
+type ValidatorProposerDuty struct {
+	Pubkey types.ValidatorPubkey `json:"pubkey"`
+	Index  uint64                `json:"index"`
+	Epoch  uint64                `json:"epoch"`
+	Slot   uint64                `json:"slot"`
+	Time   time.Time             `json:"time"`
+}
+
+type ValidatorSyncDuty struct {
+	Pubkey     types.ValidatorPubkey `json:"pubkey"`
+	Index      uint64                `json:"index"`
+	Period     uint64                `json:"period"`
+	StartEpoch uint64                `json:"startEpoch"`
+	EndEpoch   uint64                `json:"endEpoch"`
+	StartTime  time.Time             `json:"startTime"`
+	EndTime    time.Time             `json:"endTime"`
+}
+
+type ValidatorDutyFreeWindow struct {
+	StartEpoch uint64    `json:"startEpoch"`
+	EndEpoch   uint64    `json:"endEpoch"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+}
+
+type CheckForDoppelgangersResponse struct {
+	Status            string                  `json:"status"`
+	Error             string                  `json:"error"`
+	ValidatorsChecked int                     `json:"validatorsChecked"`
+	LiveValidators    []types.ValidatorPubkey `json:"liveValidators"`
+}
+
 type CanUpdateSocializeElResponse struct {
 	Status                             string         `json:"status"`
 	Error                              string         `json:"error"`
@@ -273,9 +469,10 @@ type CanWithdrawSdResponse struct {
 }
 
 type WithdrawSdResponse struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error"`
-	TxHash common.Hash `json:"txHash"`
+	Status    string                     `json:"status"`
+	Error     string                     `json:"error"`
+	TxHash    common.Hash                `json:"txHash"`
+	OfflineTx *wallet.OfflineTransaction `json:"offlineTx,omitempty"`
 }
 
 type CanClaimSdResponse struct {
@@ -381,9 +578,10 @@ type CanUpdateOperatorRewardAddress struct {
 }
 
 type SetRewardAddress struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error"`
-	TxHash common.Hash `json:"txHash"`
+	Status    string                     `json:"status"`
+	Error     string                     `json:"error"`
+	TxHash    common.Hash                `json:"txHash"`
+	OfflineTx *wallet.OfflineTransaction `json:"offlineTx,omitempty"`
 }
 
 type NodeSignResponse struct {
@@ -19,7 +19,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -39,7 +43,15 @@ type ClientStatus struct {
 	IsSynced     bool    `json:"isSynced"`
 	SyncProgress float64 `json:"syncProgress"`
 	NetworkId    uint    `json:"networkId"`
+	HealthScore  float64 `json:"healthScore"`
 	Error        string  `json:"error"`
+
+	// The latest block (Execution clients) or slot (Consensus clients) and when it was produced
+	HeadNumber      uint64    `json:"headNumber"`
+	HeadTime        time.Time `json:"headTime"`
+	FinalizedEpoch  uint64    `json:"finalizedEpoch"`
+	IsStalled       bool      `json:"isStalled"`
+	FinalityStalled bool      `json:"finalityStalled"`
 }
 
 // This is a wrapper for the manager's overall status report
@@ -47,6 +59,12 @@ type ClientManagerStatus struct {
 	PrimaryClientStatus  ClientStatus `json:"primaryEcStatus"`
 	FallbackEnabled      bool         `json:"fallbackEnabled"`
 	FallbackClientStatus ClientStatus `json:"fallbackEcStatus"`
+
+	// Statuses of the fallbacks after the first one, in order of priority
+	AdditionalFallbackStatuses []ClientStatus `json:"additionalFallbackStatuses"`
+
+	// Set if the clients disagree about the state of the chain
+	ConsistencyError string `json:"consistencyError"`
 }
 
 type ClientStatusResponse struct {
@@ -23,6 +23,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/google/uuid"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
 	"github.com/stader-labs/stader-node/stader-lib/types"
@@ -83,10 +84,12 @@ type SearchAndRecoverWalletResponse struct {
 	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
 }
 
-type RebuildWalletResponse struct {
-	Status        string                  `json:"status"`
-	Error         string                  `json:"error"`
-	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+type RebuildKeystoresResponse struct {
+	Status         string                  `json:"status"`
+	Error          string                  `json:"error"`
+	ActiveKeystore bool                    `json:"activeKeystore"`
+	RebuiltKeys    []types.ValidatorPubkey `json:"rebuiltKeys"`
+	UnchangedKeys  []types.ValidatorPubkey `json:"unchangedKeys"`
 }
 
 type ExportWalletResponse struct {
@@ -113,7 +116,19 @@ type TestMnemonicResponse struct {
 	RecoveredAddress common.Address `json:"recoveredAddress"`
 }
 
+type SetNextValidatorIndexResponse struct {
+	Status        string `json:"status"`
+	Error         string `json:"error"`
+	PreviousIndex uint64 `json:"previousIndex"`
+}
+
 type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type SignTransactionResponse struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error"`
+	SignedTx hexutil.Bytes `json:"signedTx"`
+}
@@ -29,6 +29,7 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type TxSubmissionFallback string
 
 // Enum to describe which container(s) a parameter impacts, so the Stadernode knows which
 // ones to restart upon a settings change
@@ -132,6 +133,13 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe when transactions fall back to the public Execution client if the submission RPC fails
+const (
+	TxSubmissionFallback_Never       TxSubmissionFallback = "never"
+	TxSubmissionFallback_Unreachable TxSubmissionFallback = "unreachable"
+	TxSubmissionFallback_Always      TxSubmissionFallback = "always"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
@@ -20,10 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package api
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stader-labs/stader-node/shared/services/config"
 	"github.com/stader-labs/stader-node/shared/utils/log"
 	"github.com/stader-labs/stader-node/shared/utils/math"
@@ -85,10 +87,21 @@ func PrintAndWaitForTransaction(cfg *config.StaderConfig, hash common.Hash, ec s
 	logger.Println("Waiting for the transaction to be validated...")
 
 	// Wait for the TX to be included in a block
-	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
+	if _, err := WaitForTransaction(cfg, ec, hash); err != nil {
 		return fmt.Errorf("Error waiting for transaction: %w", err)
 	}
 
 	return nil
 
 }
+
+// Wait for a TX to be confirmed, using the confirmation count and timeouts from the config
+func WaitForTransaction(cfg *config.StaderConfig, ec stader.ExecutionClient, hash common.Hash) (*types.Receipt, error) {
+	ctx := context.Background()
+	if timeout := cfg.StaderNode.GetTxWaitTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return utils.WaitForTransaction(ctx, ec, hash, cfg.StaderNode.TxConfirmations.Value.(uint64), cfg.StaderNode.GetTxDropTimeout())
+}
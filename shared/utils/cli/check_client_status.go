@@ -54,7 +54,7 @@ func CheckClientStatus(staderClient *stader.Client) error {
 	if ecMgrStatus.FallbackEnabled && bcMgrStatus.FallbackEnabled {
 
 		// Fallback EC and CC are good
-		if ecMgrStatus.FallbackClientStatus.IsSynced && bcMgrStatus.FallbackClientStatus.IsSynced {
+		if isAnyFallbackSynced(ecMgrStatus) && isAnyFallbackSynced(bcMgrStatus) {
 			fmt.Printf("%sNOTE: primary clients are not ready, using fallback clients...\n\tPrimary EC status: %s\n\tPrimary CC status: %s%s\n\n", colorYellow, primaryEcStatus, primaryBcStatus, colorReset)
 			staderClient.SetClientStatusFlags(true, true)
 			return nil
@@ -70,6 +70,19 @@ func CheckClientStatus(staderClient *stader.Client) error {
 
 }
 
+// Check if the first fallback or any of the additional ones is synced
+func isAnyFallbackSynced(mgrStatus api.ClientManagerStatus) bool {
+	if mgrStatus.FallbackClientStatus.IsSynced {
+		return true
+	}
+	for _, clientStatus := range mgrStatus.AdditionalFallbackStatuses {
+		if clientStatus.IsSynced {
+			return true
+		}
+	}
+	return false
+}
+
 func getClientStatusString(clientStatus api.ClientStatus) string {
 	if clientStatus.IsSynced {
 		return "synced and ready"
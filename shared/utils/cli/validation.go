@@ -28,6 +28,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
@@ -255,6 +256,19 @@ func ValidateTxHash(name, value string) (common.Hash, error) {
 
 }
 
+// Validate a raw transaction, signed or unsigned
+func ValidateTransaction(name, value string) (*ethtypes.Transaction, error) {
+	bytes, err := hex.DecodeString(hexutils.RemovePrefix(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s '%s': %w", name, value, err)
+	}
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(bytes); err != nil {
+		return nil, fmt.Errorf("invalid %s '%s': %w", name, value, err)
+	}
+	return tx, nil
+}
+
 // Validate a validator pubkey
 func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
 	pubkey, err := types.HexToValidatorPubkey(hexutils.RemovePrefix(value))
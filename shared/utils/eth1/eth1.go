@@ -29,7 +29,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/fatih/color"
 	"github.com/stader-labs/stader-node/shared/services"
+	apiutils "github.com/stader-labs/stader-node/shared/utils/api"
+	"github.com/stader-labs/stader-node/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
@@ -115,3 +118,56 @@ func ConvertBlockToTimestamp(c *cli.Context, blockNumber int64) (time.Time, erro
 
 	return t, nil
 }
+
+// Wait for a node transaction to be confirmed and log its cost in the transaction journal, even if it failed.
+// The transaction is mined either way, so failing to log it is only a warning; 'node tx history' fills it in later.
+func WaitForTransaction(c *cli.Context, hash common.Hash) (*types.Receipt, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := apiutils.WaitForTransaction(cfg, ec, hash)
+	if receipt != nil {
+		if recordErr := RecordTxReceipt(c, receipt); recordErr != nil {
+			logger := log.NewColorLogger(color.FgYellow)
+			logger.Printlnf("WARNING: could not record the cost of transaction %s in the transaction journal: %s", hash.Hex(), recordErr.Error())
+		}
+	}
+	return receipt, err
+}
+
+// Log the actual cost of a mined node transaction in the transaction journal
+func RecordTxReceipt(c *cli.Context, receipt *types.Receipt) error {
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Receipts don't include the price paid, so work it out from the transaction and the block's base fee
+	tx, _, err := ec.TransactionByHash(context.Background(), receipt.TxHash)
+	if err != nil {
+		return fmt.Errorf("Error getting transaction %s: %w", receipt.TxHash.Hex(), err)
+	}
+	header, err := ec.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("Error getting block %s: %w", receipt.BlockNumber, err)
+	}
+	effectiveGasPrice := tx.GasPrice()
+	if header.BaseFee != nil {
+		effectiveGasPrice = new(big.Int).Add(header.BaseFee, tx.GasTipCap())
+		if effectiveGasPrice.Cmp(tx.GasFeeCap()) > 0 {
+			effectiveGasPrice = tx.GasFeeCap()
+		}
+	}
+
+	return w.RecordTxReceipt(receipt, effectiveGasPrice)
+}
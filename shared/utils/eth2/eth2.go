@@ -21,6 +21,7 @@ package eth2
 
 import (
 	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/config"
 )
 
 const (
@@ -28,11 +29,35 @@ const (
 	MainnetCapellaForkVersion = "0x03000000"
 )
 
+// Get the fork version voluntary exits are signed with; since Deneb it's always Capella's
+func GetExitForkVersion(network config.Network) string {
+	// TODO - we currently only support mainnet and testnet envs. We will have to update this as we change n/ws
+	if network == config.Network_Mainnet {
+		return MainnetCapellaForkVersion
+	}
+	return GoerliCapellaForkVersion
+}
+
 // Get an eth2 epoch number by time
 func EpochAt(config beacon.Eth2Config, time uint64) uint64 {
 	return config.GenesisEpoch + (time-config.GenesisTime)/config.SecondsPerEpoch
 }
 
+// Get the unix timestamp at which an eth2 epoch starts
+func EpochStartTime(config beacon.Eth2Config, epoch uint64) uint64 {
+	return config.GenesisTime + (epoch-config.GenesisEpoch)*config.SecondsPerEpoch
+}
+
+// Get the unix timestamp of an eth2 slot
+func SlotTime(config beacon.Eth2Config, slot uint64) uint64 {
+	return config.GenesisTime + (slot-config.GenesisEpoch*config.SlotsPerEpoch)*config.SecondsPerSlot
+}
+
+// Get the sync committee period an eth2 epoch belongs to
+func SyncCommitteePeriodAt(config beacon.Eth2Config, epoch uint64) uint64 {
+	return epoch / config.EpochsPerSyncCommitteePeriod
+}
+
 func IsValidatorWithdrawn(validatorStatus beacon.ValidatorStatus) bool {
 	switch validatorStatus.Status {
 	case beacon.ValidatorState_WithdrawalPossible:
@@ -4,6 +4,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
 	"github.com/stader-labs/stader-node/stader-lib/contracts"
 	"github.com/stader-labs/stader-node/stader-lib/node"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
@@ -56,10 +57,53 @@ func GetAllValidatorsRegisteredWithOperator(pnr *stader.PermissionlessNodeRegist
 
 }
 
+// Get the beacon chain indices of the given validators, skipping those that aren't on the beacon chain or have been withdrawn
+func GetValidatorIndices(bc beacon.Client, pubkeys []types.ValidatorPubkey) (map[uint64]types.ValidatorPubkey, error) {
+	indexPubkeys := make(map[uint64]types.ValidatorPubkey)
+	if len(pubkeys) == 0 {
+		return indexPubkeys, nil
+	}
+
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		if eth2.IsValidatorWithdrawn(status) {
+			continue
+		}
+		indexPubkeys[status.Index] = pubkey
+	}
+
+	return indexPubkeys, nil
+}
+
 func IsValidatorTerminal(validatorInfo contracts.Validator) bool {
 	return validatorInfo.Status == 1 || validatorInfo.Status == 2
 }
 
+// Check whether a validator has no more duties, either because it never activated or because it has exited
+func IsValidatorDone(validatorInfo contracts.Validator, beaconValidatorStatus beacon.ValidatorStatus) bool {
+	return IsValidatorTerminal(validatorInfo) || HasValidatorExited(beaconValidatorStatus)
+}
+
+// Check whether the beacon chain shows a validator as exited
+func HasValidatorExited(beaconValidatorStatus beacon.ValidatorStatus) bool {
+	if !beaconValidatorStatus.Exists {
+		return false
+	}
+	switch beaconValidatorStatus.Status {
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed, beacon.ValidatorState_WithdrawalPossible, beacon.ValidatorState_WithdrawalDone:
+		return true
+	default:
+		return false
+	}
+}
+
 func GetValidatorRunningStatus(beaconValidatorStatus beacon.ValidatorStatus, validatorContractInfo contracts.Validator) (string, error) {
 	if validatorContractInfo.Status != 4 || !beaconValidatorStatus.Exists {
 		return ValidatorState[validatorContractInfo.Status], nil
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// Watches the given validators for a number of complete epochs and returns the indices of any that were seen live on the network.
+// The current epoch is skipped, since our own validator client may have attested in it before it was stopped.
+func CheckForDoppelgangers(bc beacon.Client, indices []uint64, epochs uint64, log *log.ColorLogger) ([]uint64, error) {
+
+	liveIndices := []uint64{}
+	if len(indices) == 0 || epochs == 0 {
+		return liveIndices, nil
+	}
+
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the Beacon chain config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the Beacon chain head: %w", err)
+	}
+
+	startEpoch := head.Epoch + 1
+	endEpoch := startEpoch + epochs - 1
+	liveMap := map[uint64]bool{}
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+
+		// Wait for the epoch to finish
+		epochEnd := time.Unix(int64(eth2.EpochStartTime(eth2Config, epoch+1)), 0)
+		if waitTime := time.Until(epochEnd); waitTime > 0 {
+			if log != nil {
+				log.Printlnf("Waiting %s for epoch %d to finish...", waitTime.Round(time.Second), epoch)
+			}
+			time.Sleep(waitTime)
+		}
+
+		// Check the finished epoch, and the one before it in case of late inclusions
+		checkEpochs := []uint64{epoch}
+		if epoch > startEpoch {
+			checkEpochs = append(checkEpochs, epoch-1)
+		}
+		for _, checkEpoch := range checkEpochs {
+			liveness, err := bc.GetValidatorLiveness(indices, checkEpoch)
+			if err != nil {
+				return nil, err
+			}
+			for index, isLive := range liveness {
+				if isLive {
+					liveMap[index] = true
+				}
+			}
+		}
+
+		// Stop as soon as a doppelganger is detected
+		if len(liveMap) > 0 {
+			break
+		}
+		if log != nil {
+			log.Printlnf("No validators were live in epoch %d.", epoch)
+		}
+	}
+
+	for index := range liveMap {
+		liveIndices = append(liveIndices, index)
+	}
+	sort.Slice(liveIndices, func(i, j int) bool {
+		return liveIndices[i] < liveIndices[j]
+	})
+	return liveIndices, nil
+
+}
@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stader-labs/stader-node/shared/services/beacon/client"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// A minimal beacon node serving the endpoints used by the doppelganger check, with one-second epochs
+type fakeBeaconNode struct {
+	genesisTime    int64
+	liveIndices    map[uint64]bool
+	lock           sync.Mutex
+	livenessEpochs []uint64
+}
+
+func newFakeBeaconNode(liveIndices ...uint64) *fakeBeaconNode {
+	node := &fakeBeaconNode{
+		genesisTime: time.Now().Unix() - 100,
+		liveIndices: map[uint64]bool{},
+	}
+	for _, index := range liveIndices {
+		node.liveIndices[index] = true
+	}
+	return node
+}
+
+func (n *fakeBeaconNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/eth/v1/config/spec":
+		fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"1","SLOTS_PER_EPOCH":"1","EPOCHS_PER_SYNC_COMMITTEE_PERIOD":"256"}}`)
+	case r.URL.Path == "/eth/v1/beacon/genesis":
+		fmt.Fprintf(w, `{"data":{"genesis_time":"%d","genesis_fork_version":"0x00000000","genesis_validators_root":"0x00"}}`, n.genesisTime)
+	case r.URL.Path == "/eth/v1/beacon/states/head/finality_checkpoints":
+		fmt.Fprint(w, `{"data":{"previous_justified":{"epoch":"0"},"current_justified":{"epoch":"0"},"finalized":{"epoch":"0"}}}`)
+	case strings.HasPrefix(r.URL.Path, "/eth/v1/validator/liveness/") && r.Method == http.MethodPost:
+		epoch, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/liveness/"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var indices []string
+		if err := json.NewDecoder(r.Body).Decode(&indices); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		n.lock.Lock()
+		n.livenessEpochs = append(n.livenessEpochs, epoch)
+		n.lock.Unlock()
+
+		data := []string{}
+		for _, indexString := range indices {
+			index, _ := strconv.ParseUint(indexString, 10, 64)
+			data = append(data, fmt.Sprintf(`{"index":"%d","is_live":%t}`, index, n.liveIndices[index]))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (n *fakeBeaconNode) currentEpoch() uint64 {
+	return uint64(time.Now().Unix() - n.genesisTime)
+}
+
+func TestCheckForDoppelgangersNoneLive(t *testing.T) {
+	node := newFakeBeaconNode()
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	startEpoch := node.currentEpoch()
+	liveIndices, err := CheckForDoppelgangers(client.NewStandardHttpClient(server.URL, log.NewColorLogger(color.FgHiBlue)), []uint64{1, 2, 3}, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(liveIndices) != 0 {
+		t.Errorf("expected no live validators, got %v", liveIndices)
+	}
+
+	if len(node.livenessEpochs) == 0 {
+		t.Fatal("expected liveness to be queried")
+	}
+	for _, epoch := range node.livenessEpochs {
+		if epoch <= startEpoch {
+			t.Errorf("liveness was queried for epoch %d, which started before the check", epoch)
+		}
+	}
+	if node.currentEpoch() < startEpoch+3 {
+		t.Errorf("check returned before the watched epochs finished")
+	}
+}
+
+func TestCheckForDoppelgangersLive(t *testing.T) {
+	node := newFakeBeaconNode(2)
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	liveIndices, err := CheckForDoppelgangers(client.NewStandardHttpClient(server.URL, log.NewColorLogger(color.FgHiBlue)), []uint64{1, 2, 3}, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(liveIndices) != 1 || liveIndices[0] != 2 {
+		t.Errorf("expected validator 2 to be live, got %v", liveIndices)
+	}
+
+	// The check should stop after the first epoch that has a live validator
+	if len(node.livenessEpochs) != 1 {
+		t.Errorf("expected a single liveness query, got %d", len(node.livenessEpochs))
+	}
+}
+
+func TestCheckForDoppelgangersNoValidators(t *testing.T) {
+	node := newFakeBeaconNode()
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	liveIndices, err := CheckForDoppelgangers(client.NewStandardHttpClient(server.URL, log.NewColorLogger(color.FgHiBlue)), []uint64{}, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(liveIndices) != 0 {
+		t.Errorf("expected no live validators, got %v", liveIndices)
+	}
+	if len(node.livenessEpochs) != 0 {
+		t.Errorf("expected no liveness queries, got %d", len(node.livenessEpochs))
+	}
+}
+
+func TestCheckForDoppelgangersBeaconError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := CheckForDoppelgangers(client.NewStandardHttpClient(server.URL, log.NewColorLogger(color.FgHiBlue)), []uint64{1}, 1, nil)
+	if err == nil {
+		t.Error("expected an error from an unavailable beacon node")
+	}
+}
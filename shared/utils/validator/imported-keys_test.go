@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// An imported key must be usable for the whole validator lifecycle, not just the deposit
+func TestDepositAndExitImportedKey(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := wallet.NewWallet(filepath.Join(dir, "wallet"), 1337, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(wallet.DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	w.SetImportedKeyStore(wallet.NewImportedKeyStore(filepath.Join(dir, "imported-keys")))
+
+	// Import a key the wallet didn't derive and deposit it
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	if err := w.ImportValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+		t.Fatal(err)
+	}
+	importedKey, err := w.GetImportedValidatorKey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositData, _, err := GetDepositData(importedKey.PrivateKey, common.HexToHash("0x01"), beacon.Eth2Config{GenesisForkVersion: []byte{0, 0, 0, 0}}, 1000000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types.BytesToValidatorPubkey(depositData.PublicKey) != pubkey {
+		t.Fatalf("deposited %x instead of the imported key", depositData.PublicKey)
+	}
+
+	// Look it up by pubkey the way the exit command and the presign daemon do, and sign an exit
+	validatorKey, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain := make([]byte, 32)
+	signature, signingRoot, err := GetSignedExitMessage(validatorKey, 42, 100, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blsSignature, err := eth2types.BLSSignatureFromBytes(signature.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !blsSignature.Verify(signingRoot[:], key.PublicKey()) {
+		t.Fatal("the exit wasn't signed by the imported key")
+	}
+
+	// A key that was neither derived nor imported still isn't found
+	otherKey, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetValidatorKeyByPubkey(types.BytesToValidatorPubkey(otherKey.PublicKey().Marshal())); err == nil {
+		t.Fatal("found a key that was never imported")
+	}
+}
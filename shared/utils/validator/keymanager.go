@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/client"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+// Get a client for the validator client's keymanager API, or nil if it's disabled
+func GetKeymanagerClient(cfg *config.StaderConfig) *keymanager.Client {
+	if !cfg.StaderNode.KeymanagerApi.Value.(bool) {
+		return nil
+	}
+	return keymanager.NewClient(cfg.StaderNode.GetKeymanagerApiUrl(), os.ExpandEnv(cfg.StaderNode.GetKeymanagerTokenPath()))
+}
+
+// Load validator keys into the validator client without restarting it, and give them the fee recipient the node daemon configured.
+// If they can't be loaded through the keymanager API, the validator client is restarted so it loads them from disk.
+func LoadValidatorKeys(cfg *config.StaderConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client, keys []*eth2types.BLSPrivateKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := importValidatorKeys(cfg, keys)
+	if err == nil {
+		if log != nil {
+			log.Printlnf("Loaded %d validator keys through the keymanager API", len(keys))
+		}
+		return nil
+	}
+	if log != nil {
+		log.Printlnf("Could not load validator keys through the keymanager API (%s)", err.Error())
+	}
+	return RestartValidator(cfg, bc, log, d)
+}
+
+// Import validator keys through the keymanager API, as remote keys if Web3Signer holds them
+func importValidatorKeys(cfg *config.StaderConfig, keys []*eth2types.BLSPrivateKey) error {
+	keymanagerClient := GetKeymanagerClient(cfg)
+	if keymanagerClient == nil {
+		return keymanager.ErrUnavailable
+	}
+
+	var statuses []keymanager.Status
+	var err error
+	if web3SignerUrl := cfg.StaderNode.Web3SignerUrl.Value.(string); web3SignerUrl != "" {
+		remoteKeys := make([]keymanager.RemoteKey, len(keys))
+		for i, key := range keys {
+			remoteKeys[i] = keymanager.RemoteKey{
+				Pubkey: hexutil.AddPrefix(stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex()),
+				Url:    web3SignerUrl,
+			}
+		}
+		statuses, err = keymanagerClient.ImportRemoteKeys(remoteKeys)
+	} else {
+		keystores := make([]string, len(keys))
+		passwords := make([]string, len(keys))
+		for i, key := range keys {
+			keystores[i], passwords[i], err = keymanager.EncryptKeystore(key, "")
+			if err != nil {
+				return err
+			}
+		}
+		statuses, err = keymanagerClient.ImportKeystores(keystores, passwords, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, status := range statuses {
+		if status.Status != keymanager.StatusImported && status.Status != keymanager.StatusDuplicate {
+			pubkey := stadertypes.BytesToValidatorPubkey(keys[i].PublicKey().Marshal())
+			return fmt.Errorf("validator key %s was not imported: %s %s", pubkey.Hex(), status.Status, status.Message)
+		}
+	}
+
+	// Give the new keys the node's fee recipient explicitly rather than relying on the validator client's default
+	feeRecipient, err := stader.ReadFeeRecipientFile(cfg)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := keymanagerClient.SetFeeRecipient(stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal()), feeRecipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the name of the keystore the validator client loads its keys from
+func GetValidatorClientKeystore(cfg *config.StaderConfig) string {
+	if cfg.StaderNode.Web3SignerUrl.Value.(string) != "" {
+		return "web3signer"
+	}
+	consensusClient, _ := cfg.GetSelectedConsensusClient()
+	return string(consensusClient)
+}
+
+// Remove validator keys from the validator client without restarting it.
+// If they can't be removed through the keymanager API, the validator client is restarted so it drops the keys deleted from disk.
+func UnloadValidatorKeys(cfg *config.StaderConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client, pubkeys []stadertypes.ValidatorPubkey) error {
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	err := deleteValidatorKeys(cfg, pubkeys)
+	if err == nil {
+		if log != nil {
+			log.Printlnf("Removed %d validator keys through the keymanager API", len(pubkeys))
+		}
+		return nil
+	}
+	if log != nil {
+		log.Printlnf("Could not remove validator keys through the keymanager API (%s)", err.Error())
+	}
+	return RestartValidator(cfg, bc, log, d)
+}
+
+// Delete validator keys through the keymanager API, as remote keys if Web3Signer holds them
+func deleteValidatorKeys(cfg *config.StaderConfig, pubkeys []stadertypes.ValidatorPubkey) error {
+	keymanagerClient := GetKeymanagerClient(cfg)
+	if keymanagerClient == nil {
+		return keymanager.ErrUnavailable
+	}
+
+	var statuses []keymanager.Status
+	var err error
+	if cfg.StaderNode.Web3SignerUrl.Value.(string) != "" {
+		statuses, err = keymanagerClient.DeleteRemoteKeys(pubkeys)
+	} else {
+		statuses, _, err = keymanagerClient.DeleteKeystores(pubkeys)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, status := range statuses {
+		if status.Status != keymanager.StatusDeleted && status.Status != keymanager.StatusNotActive && status.Status != keymanager.StatusNotFound {
+			return fmt.Errorf("validator key %s was not removed: %s %s", pubkeys[i].Hex(), status.Status, status.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+)
+
+// The most bytes a graffiti can hold
+const MaxGraffitiLength = 32
+
+// Graffiti file permissions; the validator client reads it
+const GraffitiFileMode = 0644
+
+// Graffiti template placeholders
+const (
+	GraffitiOperator        = "{operator}"
+	GraffitiExecutionClient = "{ec}"
+	GraffitiConsensusClient = "{cc}"
+	GraffitiValidatorClient = "{vc}"
+	GraffitiIndex           = "{index}"
+)
+
+var graffitiPlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// The values the placeholders in a graffiti template are replaced with, apart from the validator index
+type GraffitiValues struct {
+	Operator        string
+	ExecutionClient string
+	ConsensusClient string
+	ValidatorClient string
+}
+
+// Check that a graffiti template only uses known placeholders
+func ValidateGraffitiTemplate(template string) error {
+	for _, placeholder := range graffitiPlaceholderPattern.FindAllString(template, -1) {
+		switch placeholder {
+		case GraffitiOperator, GraffitiExecutionClient, GraffitiConsensusClient, GraffitiValidatorClient, GraffitiIndex:
+		default:
+			return fmt.Errorf("unknown graffiti placeholder %s; use %s, %s, %s, %s or %s", placeholder, GraffitiOperator, GraffitiExecutionClient, GraffitiConsensusClient, GraffitiValidatorClient, GraffitiIndex)
+		}
+	}
+	return nil
+}
+
+// Render the graffiti of a validator from a template, cut to the most a graffiti can hold
+func RenderGraffiti(template string, values GraffitiValues, index string) string {
+	graffiti := strings.NewReplacer(
+		GraffitiOperator, values.Operator,
+		GraffitiExecutionClient, values.ExecutionClient,
+		GraffitiConsensusClient, values.ConsensusClient,
+		GraffitiValidatorClient, values.ValidatorClient,
+		GraffitiIndex, index,
+	).Replace(template)
+
+	if len(graffiti) <= MaxGraffitiLength {
+		return graffiti
+	}
+	// Don't cut a multi-byte character in half
+	graffiti = graffiti[:MaxGraffitiLength]
+	for len(graffiti) > 0 && !utf8.ValidString(graffiti) {
+		graffiti = graffiti[:len(graffiti)-1]
+	}
+	return graffiti
+}
+
+// Get the values for a graffiti template from the operator details and the node diversity client versions
+func GetGraffitiValues(cfg *config.StaderConfig, bc beacon.Client, pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, ecVersion string) (GraffitiValues, error) {
+	operatorInfo, err := node.GetOperatorInfo(pnr, operatorId, nil)
+	if err != nil {
+		return GraffitiValues{}, fmt.Errorf("error getting operator info: %w", err)
+	}
+	bcNodeVersion, err := bc.GetNodeVersion()
+	if err != nil {
+		return GraffitiValues{}, fmt.Errorf("error getting consensus client version: %w", err)
+	}
+	validatorClientConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return GraffitiValues{}, err
+	}
+
+	return GraffitiValues{
+		Operator:        operatorInfo.OperatorName,
+		ExecutionClient: shortenClientVersion(ecVersion),
+		ConsensusClient: shortenClientVersion(bcNodeVersion.Version),
+		ValidatorClient: validatorClientConfig.GetName(),
+	}, nil
+}
+
+// Get the validators loaded in the validator client that the operator has registered and that aren't terminal
+func GetManagedValidators(keymanagerClient *keymanager.Client, pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, nodeAddress common.Address) ([]stadertypes.ValidatorPubkey, error) {
+	loadedPubkeys, err := keymanagerClient.ListPubkeys()
+	if err != nil {
+		return nil, err
+	}
+	registeredValidators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validators registered with the operator: %w", err)
+	}
+
+	managedPubkeys := []stadertypes.ValidatorPubkey{}
+	for _, pubkey := range loadedPubkeys {
+		validatorInfo, registered := registeredValidators[pubkey]
+		if !registered || stdr.IsValidatorTerminal(validatorInfo) {
+			continue
+		}
+		managedPubkeys = append(managedPubkeys, pubkey)
+	}
+	return managedPubkeys, nil
+}
+
+// Compare the graffiti and gas limit of each managed validator with the configured ones, and update the ones that differ if apply is set.
+// A blank graffiti template or a zero gas limit leaves that setting to the validator client.
+func ReconcileValidatorSettings(cfg *config.StaderConfig, bc beacon.Client, pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, nodeAddress common.Address, ecVersion string, apply bool) ([]api.ValidatorSettings, error) {
+	keymanagerClient := GetKeymanagerClient(cfg)
+	if keymanagerClient == nil {
+		return nil, keymanager.ErrUnavailable
+	}
+
+	graffitiTemplate := cfg.StaderNode.GraffitiTemplate.Value.(string)
+	gasLimit := cfg.StaderNode.ValidatorGasLimit.Value.(uint64)
+	if err := ValidateGraffitiTemplate(graffitiTemplate); err != nil {
+		return nil, err
+	}
+
+	pubkeys, err := GetManagedValidators(keymanagerClient, pnr, operatorId, nodeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var graffitiValues GraffitiValues
+	var statuses map[stadertypes.ValidatorPubkey]beacon.ValidatorStatus
+	if graffitiTemplate != "" && len(pubkeys) > 0 {
+		graffitiValues, err = GetGraffitiValues(cfg, bc, pnr, operatorId, ecVersion)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(graffitiTemplate, GraffitiIndex) {
+			statuses, err = bc.GetValidatorStatuses(pubkeys, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator statuses: %w", err)
+			}
+		}
+	}
+
+	settings := make([]api.ValidatorSettings, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		validatorSettings := api.ValidatorSettings{Pubkey: pubkey}
+
+		if graffitiTemplate != "" {
+			// Validators the beacon chain doesn't know yet have no index
+			index := ""
+			if status, exists := statuses[pubkey]; exists && status.Exists {
+				index = strconv.FormatUint(status.Index, 10)
+			}
+			validatorSettings.DesiredGraffiti = RenderGraffiti(graffitiTemplate, graffitiValues, index)
+			validatorSettings.Graffiti, err = keymanagerClient.GetGraffiti(pubkey)
+			if err != nil {
+				return nil, err
+			}
+			if apply && validatorSettings.Graffiti != validatorSettings.DesiredGraffiti {
+				if err := keymanagerClient.SetGraffiti(pubkey, validatorSettings.DesiredGraffiti); err != nil {
+					return nil, err
+				}
+				validatorSettings.Graffiti = validatorSettings.DesiredGraffiti
+				validatorSettings.Updated = true
+			}
+		}
+
+		if gasLimit != 0 {
+			validatorSettings.DesiredGasLimit = gasLimit
+			validatorSettings.GasLimit, err = keymanagerClient.GetGasLimit(pubkey)
+			if err != nil {
+				return nil, err
+			}
+			if apply && validatorSettings.GasLimit != gasLimit {
+				if err := keymanagerClient.SetGasLimit(pubkey, gasLimit); err != nil {
+					return nil, err
+				}
+				validatorSettings.GasLimit = gasLimit
+				validatorSettings.Updated = true
+			}
+		}
+
+		settings = append(settings, validatorSettings)
+	}
+	return settings, nil
+}
+
+// Remove the graffiti and gas limit overrides of the managed validators for the settings that were cleared, so the validator client's defaults apply again
+func ClearValidatorSettings(cfg *config.StaderConfig, pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, nodeAddress common.Address, clearGraffiti bool, clearGasLimit bool) error {
+	if !clearGraffiti && !clearGasLimit {
+		return nil
+	}
+	keymanagerClient := GetKeymanagerClient(cfg)
+	if keymanagerClient == nil {
+		return keymanager.ErrUnavailable
+	}
+
+	pubkeys, err := GetManagedValidators(keymanagerClient, pnr, operatorId, nodeAddress)
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range pubkeys {
+		if clearGraffiti {
+			if err := keymanagerClient.DeleteGraffiti(pubkey); err != nil {
+				return err
+			}
+		}
+		if clearGasLimit {
+			if err := keymanagerClient.DeleteGasLimit(pubkey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Write the graffiti template, rendered without a validator index, to the file the validator client takes its graffiti from when the keymanager API is disabled.
+// A blank template removes the file so the Custom Graffiti is used again. Returns true if the file changed.
+func UpdateGraffitiFile(cfg *config.StaderConfig, bc beacon.Client, pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, ecVersion string) (bool, error) {
+	path := cfg.StaderNode.GetGraffitiFilePath()
+	existing, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading graffiti file: %w", err)
+	}
+	exists := err == nil
+
+	graffitiTemplate := cfg.StaderNode.GraffitiTemplate.Value.(string)
+	if graffitiTemplate == "" {
+		if !exists {
+			return false, nil
+		}
+		if err := os.Remove(path); err != nil {
+			return false, fmt.Errorf("error removing graffiti file: %w", err)
+		}
+		return true, nil
+	}
+	if err := ValidateGraffitiTemplate(graffitiTemplate); err != nil {
+		return false, err
+	}
+
+	graffitiValues, err := GetGraffitiValues(cfg, bc, pnr, operatorId, ecVersion)
+	if err != nil {
+		return false, err
+	}
+	graffiti := RenderGraffiti(graffitiTemplate, graffitiValues, "")
+	if exists && string(existing) == graffiti {
+		return false, nil
+	}
+	if err := ioutil.WriteFile(path, []byte(graffiti), GraffitiFileMode); err != nil {
+		return false, fmt.Errorf("error writing graffiti file: %w", err)
+	}
+	return true, nil
+}
+
+// Shorten a client version such as "Geth/v1.13.5-stable/linux-amd64/go1.21.4" to its name and version
+func shortenClientVersion(version string) string {
+	parts := strings.SplitN(version, "/", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "/" + parts[1]
+}
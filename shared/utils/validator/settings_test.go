@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestValidateGraffitiTemplate(t *testing.T) {
+
+	tests := []struct {
+		template string
+		valid    bool
+	}{
+		{"", true},
+		{"plain graffiti", true},
+		{"{operator} {ec}/{cc}/{vc} #{index}", true},
+		{"{operator}{operator}", true},
+		{"{name}", false},
+		{"{Operator}", false},
+		{"{ operator }", false},
+		{"{}", false},
+		{"{operator} {validator}", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			err := ValidateGraffitiTemplate(test.template)
+			if (err == nil) != test.valid {
+				t.Fatalf("got %v, expected valid to be %t", err, test.valid)
+			}
+		})
+	}
+
+}
+
+func TestRenderGraffiti(t *testing.T) {
+	values := GraffitiValues{
+		Operator:        "Acme",
+		ExecutionClient: "Geth/v1.13.0",
+		ConsensusClient: "Lighthouse/v4.5.0",
+		ValidatorClient: "Lighthouse",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		index    string
+		expected string
+	}{
+		{"no placeholders", "hello", "7", "hello"},
+		{"every placeholder", "{operator}|{vc}|{index}", "7", "Acme|Lighthouse|7"},
+		{"no index", "{operator} #{index}", "", "Acme #"},
+		{"unknown placeholders are left as they are", "{operator} {other}", "7", "Acme {other}"},
+		{"cut to 32 bytes", "{operator} {ec} {cc}", "7", "Acme Geth/v1.13.0 Lighthouse/v4."},
+		{"exactly 32 bytes", strings.Repeat("a", 32), "", strings.Repeat("a", 32)},
+		// "é" takes 2 bytes, so the 32nd byte would split it
+		{"multi-byte character isn't split", strings.Repeat("a", 31) + "é", "", strings.Repeat("a", 31)},
+		{"multi-byte character that fits", strings.Repeat("a", 30) + "é", "", strings.Repeat("a", 30) + "é"},
+		// "🚀" takes 4 bytes
+		{"emoji isn't split", "{operator} " + strings.Repeat("🚀", 7), "", "Acme " + strings.Repeat("🚀", 6)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graffiti := RenderGraffiti(test.template, values, test.index)
+			if graffiti != test.expected {
+				t.Fatalf("got %q, expected %q", graffiti, test.expected)
+			}
+			if len(graffiti) > MaxGraffitiLength || !utf8.ValidString(graffiti) {
+				t.Fatalf("%q isn't a valid graffiti", graffiti)
+			}
+		})
+	}
+}
+
+func TestShortenClientVersion(t *testing.T) {
+	tests := map[string]string{
+		"Geth/v1.13.0-stable-3f907d6a/linux-amd64/go1.21.1": "Geth/v1.13.0-stable-3f907d6a",
+		"Lighthouse/v4.5.0-441fc16":                         "Lighthouse/v4.5.0-441fc16",
+		"unknown":                                           "unknown",
+	}
+	for version, expected := range tests {
+		if shortened := shortenClientVersion(version); shortened != expected {
+			t.Fatalf("got %q for %q, expected %q", shortened, version, expected)
+		}
+	}
+}
@@ -28,6 +28,23 @@ import (
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, [32]byte, error) {
 
+	// Get signing root
+	srHash, err := GetExitMessageSigningRoot(validatorIndex, epoch, signatureDomain)
+	if err != nil {
+		return types.ValidatorSignature{}, [32]byte{}, err
+	}
+
+	// Sign message
+	signature := validatorKey.Sign(srHash[:]).Marshal()
+
+	// Return
+	return types.BytesToValidatorSignature(signature), srHash, nil
+
+}
+
+// Get the signing root of a voluntary exit message for a given validator index
+func GetExitMessageSigningRoot(validatorIndex uint64, epoch uint64, signatureDomain []byte) ([32]byte, error) {
+
 	// Build voluntary exit message
 	exitMessage := eth2.VoluntaryExit{
 		Epoch:          epoch,
@@ -37,7 +54,7 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	// Get object root
 	or, err := exitMessage.HashTreeRoot()
 	if err != nil {
-		return types.ValidatorSignature{}, [32]byte{}, err
+		return [32]byte{}, err
 	}
 
 	// Get signing root
@@ -45,16 +62,6 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 		ObjectRoot: or[:],
 		Domain:     signatureDomain,
 	}
-
-	srHash, err := sr.HashTreeRoot()
-	if err != nil {
-		return types.ValidatorSignature{}, [32]byte{}, err
-	}
-
-	// Sign message
-	signature := validatorKey.Sign(srHash[:]).Marshal()
-
-	// Return
-	return types.BytesToValidatorSignature(signature), srHash, nil
+	return sr.HashTreeRoot()
 
 }
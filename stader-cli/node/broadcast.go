@@ -0,0 +1,81 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/math"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+func broadcastTransaction(c *cli.Context, path string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Load the signed transaction
+	offlineTx, err := wallet.LoadOfflineTransaction(path)
+	if err != nil {
+		return err
+	}
+	if len(offlineTx.SignedTx) == 0 {
+		return fmt.Errorf("%s hasn't been signed yet; sign it on your offline machine with `stader-cli wallet sign-tx` first", path)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(offlineTx.SignedTx); err != nil {
+		return fmt.Errorf("Could not decode the signed transaction in %s: %w", path, err)
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("Transaction from %s (%s):\n", offlineTx.From.Hex(), offlineTx.Action)
+	if tx.To() != nil {
+		fmt.Printf("\tTo:       %s\n", tx.To().Hex())
+	}
+	fmt.Printf("\tValue:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(tx.Value()), 6))
+	fmt.Printf("\tNonce:    %d\n", tx.Nonce())
+	fmt.Printf("\tMax fee:  %.6f gwei (%.6f gwei priority fee), gas limit %d\n\n", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(tx.GasTipCap()), tx.Gas())
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to send this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Send it
+	response, err := staderClient.NodeBroadcast(offlineTx.SignedTx)
+	if err != nil {
+		return err
+	}
+
+	cliutils.PrintTransactionHash(staderClient, response.TxHash)
+	if _, err = staderClient.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("Successfully sent the transaction.")
+	return nil
+
+}
+
+// Save a transaction exported for offline signing and explain the next steps
+func saveOfflineTransaction(path string, offlineTx *wallet.OfflineTransaction) error {
+	if err := offlineTx.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("The unsigned transaction was written to %s.\n", path)
+	fmt.Println("Copy it to your offline machine and sign it with `stader-cli wallet sign-tx`, then send the signed file from this machine with `stader-cli node broadcast`.")
+	return nil
+}
@@ -159,6 +159,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm token send",
 					},
+					cli.StringFlag{
+						Name:  "offline-export",
+						Usage: "Write the transaction unsigned to this file instead of signing and sending it, so it can be signed on an offline machine with `stader-cli wallet sign-tx`",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -238,6 +242,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm withdraw sd collateral",
 					},
+					cli.StringFlag{
+						Name:  "offline-export",
+						Usage: "Write the transaction unsigned to this file instead of signing and sending it, so it can be signed on an offline machine with `stader-cli wallet sign-tx`",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -331,6 +339,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm claim of rewards",
 					},
+					cli.StringFlag{
+						Name:  "offline-export",
+						Usage: "Write the transaction unsigned to this file instead of signing and sending it, so it can be signed on an offline machine with `stader-cli wallet sign-tx`",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -368,6 +380,138 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return nodeApproveSd(c)
 				},
 			},
+			{
+				Name:      "broadcast",
+				Usage:     "Send a node account transaction that was signed offline with `stader-cli wallet sign-tx`",
+				UsageText: "stader-cli node broadcast [options] signed-tx-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm sending the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastTransaction(c, c.Args().Get(0))
+
+				},
+			},
+			{
+				Name:      "tx-history",
+				Usage:     "Show the journal of transactions sent by the node account, with their purpose and cost",
+				UsageText: "stader-cli node tx-history [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The output format: 'table', 'csv' or 'json'",
+						Value: "table",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "Write the history to this file instead of the terminal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					format := c.String("format")
+					switch format {
+					case "table", "csv", "json":
+					default:
+						return fmt.Errorf("Invalid format '%s'; must be 'table', 'csv' or 'json'", format)
+					}
+
+					// Run
+					return getTransactionHistory(c, format, c.String("output"))
+
+				},
+			},
+			{
+				Name:  "tx",
+				Usage: "Manage the transactions sent by the node account",
+				Subcommands: []cli.Command{
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the pending and recent transactions sent by the node account",
+						UsageText: "stader-cli node tx list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return listTransactions(c)
+
+						},
+					},
+					{
+						Name:      "speed-up",
+						Aliases:   []string{"s"},
+						Usage:     "Resend a pending transaction with higher fees",
+						UsageText: "stader-cli node tx speed-up [options] tx-hash",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the speed-up",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return replaceTransaction(c, hash, false)
+
+						},
+					},
+					{
+						Name:      "cancel",
+						Aliases:   []string{"c"},
+						Usage:     "Cancel a pending transaction by replacing it with an empty transfer to the node account",
+						UsageText: "stader-cli node tx cancel [options] tx-hash",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the cancellation",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return replaceTransaction(c, hash, true)
+
+						},
+					},
+				},
+			},
 		},
 	})
 }
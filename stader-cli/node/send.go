@@ -41,6 +41,10 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddressOrENS strin
 	}
 	defer staderClient.Close()
 
+	// Export the transaction instead of sending it if requested
+	offlineExportPath := c.String("offline-export")
+	staderClient.SetOfflineExport(offlineExportPath != "")
+
 	// Check and assign the EC status
 	err = cliutils.CheckClientStatus(staderClient)
 	if err != nil {
@@ -88,6 +92,9 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddressOrENS strin
 	if err != nil {
 		return err
 	}
+	if response.OfflineTx != nil {
+		return saveOfflineTransaction(offlineExportPath, response.OfflineTx)
+	}
 
 	fmt.Printf("Sending %s to %s...\n", token, toAddressString)
 	cliutils.PrintTransactionHash(staderClient, response.TxHash)
@@ -21,10 +21,13 @@ package node
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 )
 
@@ -48,57 +51,93 @@ func getSyncProgress(c *cli.Context) error {
 		return err
 	}
 
+	// Print the status of each client
+	printSyncStatus(os.Stdout, status)
+
+	// Return
+	return nil
+
+}
+
+// Print the sync status of every client, and warn about problems with the chain
+func printSyncStatus(w io.Writer, status api.NodeSyncProgressResponse) {
+
 	// Print EC status
 	if status.EcStatus.PrimaryClientStatus.Error != "" {
-		fmt.Printf("Your primary execution client is unavailable (%s).\n", status.EcStatus.PrimaryClientStatus.Error)
+		fmt.Fprintf(w, "Your primary execution client is unavailable (%s).\n", status.EcStatus.PrimaryClientStatus.Error)
 	} else if status.EcStatus.PrimaryClientStatus.IsSynced {
-		fmt.Print("Your primary execution client is fully synced.\n")
+		fmt.Fprint(w, "Your primary execution client is fully synced.\n")
 	} else {
-		fmt.Printf("Your primary execution client is still syncing (%0.2f%%).\n", status.EcStatus.PrimaryClientStatus.SyncProgress*100)
+		fmt.Fprintf(w, "Your primary execution client is still syncing (%0.2f%%).\n", status.EcStatus.PrimaryClientStatus.SyncProgress*100)
 		if status.EcStatus.PrimaryClientStatus.SyncProgress == 0 {
-			fmt.Println("\tNOTE: your execution client may not report sync progress.\n\tYou should check its logs to review it.")
+			fmt.Fprintln(w, "\tNOTE: your execution client may not report sync progress.\n\tYou should check its logs to review it.")
 		}
 	}
 
 	// Print fallback EC status
 	if status.EcStatus.FallbackEnabled {
 		if status.EcStatus.FallbackClientStatus.Error != "" {
-			fmt.Printf("Your fallback execution client is unavailable (%s).\n", status.EcStatus.FallbackClientStatus.Error)
+			fmt.Fprintf(w, "Your fallback execution client is unavailable (%s).\n", status.EcStatus.FallbackClientStatus.Error)
 		} else if status.EcStatus.FallbackClientStatus.IsSynced {
-			fmt.Print("Your fallback execution client is fully synced.\n")
+			fmt.Fprint(w, "Your fallback execution client is fully synced.\n")
 		} else {
-			fmt.Printf("Your fallback execution client is still syncing (%0.2f%%).\n", status.EcStatus.FallbackClientStatus.SyncProgress*100)
+			fmt.Fprintf(w, "Your fallback execution client is still syncing (%0.2f%%).\n", status.EcStatus.FallbackClientStatus.SyncProgress*100)
 			if status.EcStatus.FallbackClientStatus.SyncProgress == 0 {
-				fmt.Println("\tNOTE: your execution client may not report sync progress.\n\tYou should check your its logs to review it.")
+				fmt.Fprintln(w, "\tNOTE: your execution client may not report sync progress.\n\tYou should check its logs to review it.")
 			}
 		}
+		printAdditionalFallbackStatuses(w, "execution", status.EcStatus.AdditionalFallbackStatuses)
 	} else {
-		fmt.Printf("You do not have a fallback execution client enabled.\n")
+		fmt.Fprintf(w, "You do not have a fallback execution client enabled.\n")
+	}
+
+	if status.EcStatus.ConsistencyError != "" {
+		fmt.Fprintf(w, "%sWARNING: Your execution clients disagree about the state of the chain (%s).%s\n", colorYellow, status.EcStatus.ConsistencyError, colorReset)
 	}
 
 	// Print CC status
 	if status.BcStatus.PrimaryClientStatus.Error != "" {
-		fmt.Printf("Your primary consensus client is unavailable (%s).\n", status.BcStatus.PrimaryClientStatus.Error)
+		fmt.Fprintf(w, "Your primary consensus client is unavailable (%s).\n", status.BcStatus.PrimaryClientStatus.Error)
 	} else if status.BcStatus.PrimaryClientStatus.IsSynced {
-		fmt.Print("Your primary consensus client is fully synced.\n")
+		fmt.Fprint(w, "Your primary consensus client is fully synced.\n")
 	} else {
-		fmt.Printf("Your primary consensus client is still syncing (%0.2f%%).\n", status.BcStatus.PrimaryClientStatus.SyncProgress*100)
+		fmt.Fprintf(w, "Your primary consensus client is still syncing (%0.2f%%).\n", status.BcStatus.PrimaryClientStatus.SyncProgress*100)
 	}
 
 	// Print fallback CC status
 	if status.BcStatus.FallbackEnabled {
 		if status.BcStatus.FallbackClientStatus.Error != "" {
-			fmt.Printf("Your fallback consensus client is unavailable (%s).\n", status.BcStatus.FallbackClientStatus.Error)
+			fmt.Fprintf(w, "Your fallback consensus client is unavailable (%s).\n", status.BcStatus.FallbackClientStatus.Error)
 		} else if status.BcStatus.FallbackClientStatus.IsSynced {
-			fmt.Print("Your fallback consensus client is fully synced.\n")
+			fmt.Fprint(w, "Your fallback consensus client is fully synced.\n")
 		} else {
-			fmt.Printf("Your fallback consensus client is still syncing (%0.2f%%).\n", status.BcStatus.FallbackClientStatus.SyncProgress*100)
+			fmt.Fprintf(w, "Your fallback consensus client is still syncing (%0.2f%%).\n", status.BcStatus.FallbackClientStatus.SyncProgress*100)
 		}
+		printAdditionalFallbackStatuses(w, "consensus", status.BcStatus.AdditionalFallbackStatuses)
 	} else {
-		fmt.Printf("You do not have a fallback consensus client enabled.\n")
+		fmt.Fprintf(w, "You do not have a fallback consensus client enabled.\n")
 	}
 
-	// Return
-	return nil
+	// Warn if the chain stopped finalizing; clients that fell behind the others already report it as an error
+	bcStatuses := append([]api.ClientStatus{status.BcStatus.PrimaryClientStatus, status.BcStatus.FallbackClientStatus}, status.BcStatus.AdditionalFallbackStatuses...)
+	for _, clientStatus := range bcStatuses {
+		if clientStatus.FinalityStalled && clientStatus.Error == "" {
+			fmt.Fprintf(w, "%sWARNING: The network has not finalized an epoch since epoch %d.%s\n", colorYellow, clientStatus.FinalizedEpoch, colorReset)
+			break
+		}
+	}
 
 }
+
+// Print the status of the fallback clients after the first one
+func printAdditionalFallbackStatuses(w io.Writer, clientType string, statuses []api.ClientStatus) {
+	for i, clientStatus := range statuses {
+		if clientStatus.Error != "" {
+			fmt.Fprintf(w, "Your fallback %s client #%d is unavailable (%s).\n", clientType, i+2, clientStatus.Error)
+		} else if clientStatus.IsSynced {
+			fmt.Fprintf(w, "Your fallback %s client #%d is fully synced.\n", clientType, i+2)
+		} else {
+			fmt.Fprintf(w, "Your fallback %s client #%d is still syncing (%0.2f%%).\n", clientType, i+2, clientStatus.SyncProgress*100)
+		}
+	}
+}
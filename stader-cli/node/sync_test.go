@@ -0,0 +1,94 @@
+package node
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func TestPrintSyncStatus(t *testing.T) {
+	synced := api.ClientStatus{IsWorking: true, IsSynced: true, SyncProgress: 1}
+	stalled := api.ClientStatus{IsWorking: true, IsStalled: true, HeadNumber: 100, Error: "Client claims to have finished syncing, but its last block (100) was from 10m0s ago. It is likely stuck or doesn't have enough peers"}
+	notFinalizing := api.ClientStatus{IsWorking: true, IsSynced: true, SyncProgress: 1, FinalityStalled: true, FinalizedEpoch: 42}
+	finalityWarning := "WARNING: The network has not finalized an epoch since epoch 42."
+
+	tests := []struct {
+		name       string
+		status     api.NodeSyncProgressResponse
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name: "all synced",
+			status: api.NodeSyncProgressResponse{
+				EcStatus: api.ClientManagerStatus{PrimaryClientStatus: synced},
+				BcStatus: api.ClientManagerStatus{PrimaryClientStatus: synced},
+			},
+			expected: []string{
+				"Your primary execution client is fully synced.",
+				"You do not have a fallback execution client enabled.",
+				"Your primary consensus client is fully synced.",
+			},
+			unexpected: []string{"WARNING"},
+		},
+		{
+			name: "stalled primary with fallbacks",
+			status: api.NodeSyncProgressResponse{
+				EcStatus: api.ClientManagerStatus{
+					PrimaryClientStatus:        stalled,
+					FallbackEnabled:            true,
+					FallbackClientStatus:       synced,
+					AdditionalFallbackStatuses: []api.ClientStatus{{IsWorking: true, SyncProgress: 0.5}},
+				},
+				BcStatus: api.ClientManagerStatus{PrimaryClientStatus: synced},
+			},
+			expected: []string{
+				"Your primary execution client is unavailable (Client claims to have finished syncing, but its last block (100) was from 10m0s ago.",
+				"Your fallback execution client is fully synced.",
+				"Your fallback execution client #2 is still syncing (50.00%).",
+			},
+		},
+		{
+			name: "network not finalizing is warned about once",
+			status: api.NodeSyncProgressResponse{
+				BcStatus: api.ClientManagerStatus{PrimaryClientStatus: notFinalizing, FallbackEnabled: true, FallbackClientStatus: notFinalizing},
+			},
+			expected: []string{finalityWarning},
+		},
+		{
+			// A client that fell behind the others already reports it as an error
+			name: "client behind on finality",
+			status: api.NodeSyncProgressResponse{
+				BcStatus: api.ClientManagerStatus{PrimaryClientStatus: api.ClientStatus{FinalityStalled: true, FinalizedEpoch: 42, Error: "finality is behind the other clients"}},
+			},
+			expected:   []string{"Your primary consensus client is unavailable (finality is behind the other clients)."},
+			unexpected: []string{finalityWarning},
+		},
+		{
+			name: "clients disagree",
+			status: api.NodeSyncProgressResponse{
+				EcStatus: api.ClientManagerStatus{PrimaryClientStatus: synced, ConsistencyError: "block 100 differs"},
+			},
+			expected: []string{"WARNING: Your execution clients disagree about the state of the chain (block 100 differs)."},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			printSyncStatus(&output, test.status)
+			for _, expected := range test.expected {
+				if count := strings.Count(output.String(), expected); count != 1 {
+					t.Fatalf("expected %q once, found it %d times in:\n%s", expected, count, output.String())
+				}
+			}
+			for _, unexpected := range test.unexpected {
+				if strings.Contains(output.String(), unexpected) {
+					t.Fatalf("didn't expect %q in:\n%s", unexpected, output.String())
+				}
+			}
+		})
+	}
+}
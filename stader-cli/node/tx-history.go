@@ -0,0 +1,153 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/utils/math"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+func getTransactionHistory(c *cli.Context, format string, outputPath string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get the journal
+	response, err := staderClient.NodeTxHistory()
+	if err != nil {
+		return err
+	}
+
+	// Write to a file if requested
+	var output io.Writer = os.Stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", outputPath, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(response.Transactions); err != nil {
+			return fmt.Errorf("Error writing transaction history: %w", err)
+		}
+	case "csv":
+		if err := writeTransactionHistoryCsv(output, response.Transactions); err != nil {
+			return fmt.Errorf("Error writing transaction history: %w", err)
+		}
+	default:
+		printTransactionHistory(output, response.Transactions)
+	}
+
+	if outputPath != "" {
+		fmt.Printf("Wrote %d transaction(s) to %s.\n", len(response.Transactions), outputPath)
+	}
+	return nil
+
+}
+
+// Print the journal for reading in the terminal, newest first
+func printTransactionHistory(output io.Writer, transactions []wallet.TxJournalEntry) {
+	if len(transactions) == 0 {
+		fmt.Fprintln(output, "The node account hasn't sent any transactions from this machine yet.")
+		return
+	}
+
+	totalFee := big.NewInt(0)
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		fmt.Fprintf(output, "%s  %s\n", transaction.SentAt.Format("2006-01-02 15:04:05 MST"), transaction.Action)
+		fmt.Fprintf(output, "\tHash:     %s\n", transaction.Hash.Hex())
+		if transaction.To != nil {
+			fmt.Fprintf(output, "\tTo:       %s\n", transaction.To.Hex())
+		}
+		fmt.Fprintf(output, "\tValue:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(transaction.ValueWei), 6))
+		if transaction.Dropped {
+			fmt.Fprintf(output, "\tStatus:   never mined; it was replaced or never broadcast\n\n")
+			continue
+		}
+		if !transaction.Mined {
+			fmt.Fprintf(output, "\tGas:      %d limit, not confirmed as mined yet\n\n", transaction.GasLimit)
+			continue
+		}
+		status := "succeeded"
+		if !transaction.Succeeded {
+			status = "failed"
+		}
+		fmt.Fprintf(output, "\tStatus:   %s in block %d\n", status, transaction.BlockNumber)
+		fmt.Fprintf(output, "\tGas:      %d used of %d limit\n", transaction.GasUsed, transaction.GasLimit)
+		if transaction.FeeWei != nil {
+			fmt.Fprintf(output, "\tFee:      %.6f ETH (%.2f gwei per gas)\n", math.RoundDown(eth.WeiToEth(transaction.FeeWei), 6), eth.WeiToGwei(transaction.EffectiveGasPriceWei))
+			totalFee.Add(totalFee, transaction.FeeWei)
+		}
+		fmt.Fprintln(output)
+	}
+	fmt.Fprintf(output, "Total fees paid by mined transactions: %.6f ETH\n", math.RoundDown(eth.WeiToEth(totalFee), 6))
+}
+
+// Write the journal as CSV for accounting, oldest first
+func writeTransactionHistoryCsv(output io.Writer, transactions []wallet.TxJournalEntry) error {
+	writer := csv.NewWriter(output)
+	err := writer.Write([]string{"sent_at", "action", "hash", "to", "value_wei", "nonce", "gas_limit", "mined", "dropped", "mined_at", "block_number", "succeeded", "gas_used", "effective_gas_price_wei", "fee_wei"})
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		to := ""
+		if transaction.To != nil {
+			to = transaction.To.Hex()
+		}
+		minedAt := ""
+		if transaction.Mined {
+			minedAt = transaction.MinedAt.UTC().Format(time.RFC3339)
+		}
+		err := writer.Write([]string{
+			transaction.SentAt.UTC().Format(time.RFC3339),
+			transaction.Action,
+			transaction.Hash.Hex(),
+			to,
+			bigIntToString(transaction.ValueWei),
+			strconv.FormatUint(transaction.Nonce, 10),
+			strconv.FormatUint(transaction.GasLimit, 10),
+			strconv.FormatBool(transaction.Mined),
+			strconv.FormatBool(transaction.Dropped),
+			minedAt,
+			strconv.FormatUint(transaction.BlockNumber, 10),
+			strconv.FormatBool(transaction.Succeeded),
+			strconv.FormatUint(transaction.GasUsed, 10),
+			bigIntToString(transaction.EffectiveGasPriceWei),
+			bigIntToString(transaction.FeeWei),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
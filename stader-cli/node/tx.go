@@ -0,0 +1,147 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/gas"
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/math"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+func listTransactions(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Get the transactions
+	response, err := staderClient.NodeTxList()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The node account's next nonce is %d", response.PendingNonce)
+	if response.PendingNonce > response.ConfirmedNonce {
+		fmt.Printf(" (%d transaction(s) waiting to be included)", response.PendingNonce-response.ConfirmedNonce)
+	}
+	fmt.Println(".")
+	if len(response.Transactions) == 0 {
+		fmt.Println("The node account hasn't sent any transactions from this machine yet.")
+		return nil
+	}
+	fmt.Println()
+
+	for _, transaction := range response.Transactions {
+		printTransaction(transaction)
+	}
+	return nil
+
+}
+
+func replaceTransaction(c *cli.Context, hash common.Hash, cancel bool) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Check the transaction can be replaced
+	var canReplace api.CanNodeReplaceTxResponse
+	if cancel {
+		canReplace, err = staderClient.CanNodeTxCancel(hash)
+	} else {
+		canReplace, err = staderClient.CanNodeTxSpeedUp(hash)
+	}
+	if err != nil {
+		return err
+	}
+	if !canReplace.CanReplace {
+		fmt.Println("Cannot replace the transaction:")
+		if canReplace.NotFound {
+			fmt.Println("The transaction was not sent by the node account from this machine.")
+		}
+		if canReplace.AlreadyMined {
+			fmt.Printf("The transaction's nonce has already been used (status: %s).\n", canReplace.Transaction.Status)
+		}
+		return nil
+	}
+	printTransaction(canReplace.Transaction)
+
+	// Assign max fees
+	fmt.Printf("The replacement must have a max fee of at least %.2f gwei and a priority fee of at least %.2f gwei; lower values will be raised to these.\n",
+		eth.WeiToGwei(canReplace.MinMaxFeeWei),
+		eth.WeiToGwei(canReplace.MinPriorityFeeWei))
+	err = gas.AssignMaxFeeAndLimit(canReplace.GasInfo, staderClient, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	action := "speed up"
+	if cancel {
+		action = "cancel"
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to %s transaction %s?", action, hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Replace the transaction
+	var response api.NodeReplaceTxResponse
+	if cancel {
+		response, err = staderClient.NodeTxCancel(hash)
+	} else {
+		response, err = staderClient.NodeTxSpeedUp(hash)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replacing transaction %s...\n", hash.Hex())
+	cliutils.PrintTransactionHash(staderClient, response.TxHash)
+	if _, err = staderClient.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully replaced transaction %s with %s.\n", hash.Hex(), response.TxHash.Hex())
+	return nil
+
+}
+
+// Print the details of a node account transaction
+func printTransaction(transaction api.NodeTransaction) {
+	fmt.Printf("%s (nonce %d)\n", transaction.Hash.Hex(), transaction.Nonce)
+	fmt.Printf("\tStatus:   %s", transaction.Status)
+	if transaction.BlockNumber != 0 {
+		fmt.Printf(" in block %d", transaction.BlockNumber)
+	}
+	fmt.Println()
+	if transaction.To != nil {
+		fmt.Printf("\tTo:       %s\n", transaction.To.Hex())
+	}
+	fmt.Printf("\tValue:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(transaction.ValueWei), 6))
+	fmt.Printf("\tMax fee:  %.2f gwei (priority fee %.2f gwei), gas limit %d\n", eth.WeiToGwei(transaction.MaxFeeWei), eth.WeiToGwei(transaction.MaxPriorityFeeWei), transaction.GasLimit)
+	fmt.Printf("\tSigned:   %s\n\n", transaction.SignedAt.Format("2006-01-02 15:04:05 MST"))
+}
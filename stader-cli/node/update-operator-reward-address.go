@@ -29,6 +29,10 @@ func SetRewardAddress(c *cli.Context, operatorRewardAddress common.Address) erro
 	}
 	defer staderClient.Close()
 
+	// Export the transaction instead of sending it if requested
+	offlineExportPath := c.String("offline-export")
+	staderClient.SetOfflineExport(offlineExportPath != "")
+
 	cfg, _, err := staderClient.LoadConfig()
 	if err != nil {
 		return err
@@ -91,6 +95,9 @@ Do you wish to proceed with the Reward Address change?`
 	if err != nil {
 		return err
 	}
+	if response.OfflineTx != nil {
+		return saveOfflineTransaction(offlineExportPath, response.OfflineTx)
+	}
 
 	fmt.Println("Updating operator reward address...")
 
@@ -18,6 +18,10 @@ func WithdrawSd(c *cli.Context) error {
 	}
 	defer staderClient.Close()
 
+	// Export the transaction instead of sending it if requested
+	offlineExportPath := c.String("offline-export")
+	staderClient.SetOfflineExport(offlineExportPath != "")
+
 	// Check and assign the EC status
 	err = cliutils.CheckClientStatus(staderClient)
 	if err != nil {
@@ -61,6 +65,9 @@ func WithdrawSd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if res.OfflineTx != nil {
+		return saveOfflineTransaction(offlineExportPath, res.OfflineTx)
+	}
 
 	fmt.Printf("Withdrawing %s SD from the collateral contract.\n", amountInString)
 	cliutils.PrintTransactionHash(staderClient, res.TxHash)
@@ -242,9 +242,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "ignore-slash-timer",
 						Usage: "Bypass the safety timer that forces a delay when switching to a new ETH2 client",
 					},
+					cli.BoolFlag{
+						Name:  "ignore-doppelganger-check",
+						Usage: "Start the validator client without checking whether its keys are already live elsewhere",
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
-						Usage: "Ignore service config prompt after upgrading",
+						Usage: "Ignore service config prompt after upgrading, and start the validator client if the doppelganger check couldn't run",
 					},
 				},
 				Action: func(c *cli.Context) error {
@@ -240,6 +240,7 @@ func handleUI(
 		if err != nil {
 			return false, nil, err
 		}
+		addFallbackUIFields()
 		cSaved, cOpenWizard, newCSettings := configuration.Run(&oldCSetting)
 		cfg, err := updateConfigFromUISetting(cfg, *newCSettings)
 
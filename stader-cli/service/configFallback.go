@@ -20,10 +20,58 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"sync"
+
+	uiconfig "github.com/stader-labs/ethcli-ui/configuration/config"
+	uiutils "github.com/stader-labs/ethcli-ui/configuration/utils"
 	stdCf "github.com/stader-labs/stader-node/shared/services/config"
 	"github.com/stader-labs/stader-node/shared/types/config"
 )
 
+// Fallback page fields the UI package doesn't define
+const (
+	fcTrueAdditionalExecutionClientUrls = "Fc_true_additional_execution_client_urls"
+	fcTrueAdditionalBeaconNodeUrls      = "Fc_true_additional_beacon_node_urls"
+
+	// Matches the width of the UI package's own descriptions
+	descriptionSidebarWidth = 38
+)
+
+var addFallbackUIFieldsOnce sync.Once
+
+// Add the additional fallback URLs to the fallback page, below the fallback client URLs.
+// The UI package builds its pages from ConfigurationFields, so they have to be added there before it runs.
+func addFallbackUIFields() {
+	addFallbackUIFieldsOnce.Do(func() {
+		fields := uiconfig.ConfigurationFields[uiconfig.Categories.Option.FallbackClients]
+		for _, field := range fields {
+			if field.Key != keys.Fc_use_fallback_clients {
+				continue
+			}
+			field.Children["true"] = append(field.Children["true"],
+				uiconfig.FormFieldType{
+					Label: "Additional Execution Client URLs",
+					Key:   fcTrueAdditionalExecutionClientUrls,
+					Type:  "text",
+					Description: uiutils.AddNewLines(`Additional Execution Client URLs
+
+A comma-separated list of further Execution client HTTP API endpoints to use if the primary and fallback are unavailable, in order of priority.
+The Stader Node sends requests to the healthiest of them.`, descriptionSidebarWidth),
+				},
+				uiconfig.FormFieldType{
+					Label: "Additional Beacon Node URLs",
+					Key:   fcTrueAdditionalBeaconNodeUrls,
+					Type:  "text",
+					Description: uiutils.AddNewLines(`Additional Beacon Node URLs
+
+A comma-separated list of further Beacon API endpoints to use if the primary and fallback are unavailable, in order of priority.
+Note: These are only used by the Stader Node; your Validator client only uses the primary and fallback.`, descriptionSidebarWidth),
+				},
+			)
+		}
+	})
+}
+
 func setUIFallbackClient(cfg *stdCf.StaderConfig, newSettings map[string]interface{}) error {
 	newSettings[keys.Fc_use_fallback_clients] = cfg.UseFallbackClients.Value.(bool)
 	newSettings[keys.Fc_true_reconnect_delay] = cfg.ReconnectDelay.Value
@@ -39,6 +87,8 @@ func setUIFallbackClient(cfg *stdCf.StaderConfig, newSettings map[string]interfa
 	}
 
 	newSettings[keys.Fc_true_beacon_node_json_rpc_url] = cfg.FallbackPrysm.JsonRpcUrl.Value
+	newSettings[fcTrueAdditionalExecutionClientUrls] = cfg.FallbackNormal.AdditionalEcHttpUrls.Value
+	newSettings[fcTrueAdditionalBeaconNodeUrls] = cfg.FallbackNormal.AdditionalCcHttpUrls.Value
 	return nil
 }
 
@@ -53,6 +103,29 @@ func updateFallbackClient(cfg *stdCf.StaderConfig, newSettings map[string]interf
 		cfg.FallbackPrysm.EcHttpUrl.Value = newSettings[keys.Fc_true_execution_client_url]
 		cfg.FallbackPrysm.CcHttpUrl.Value = newSettings[keys.Fc_true_beacon_node_url]
 		cfg.FallbackPrysm.JsonRpcUrl.Value = newSettings[keys.Fc_true_beacon_node_json_rpc_url]
+
+		// The managers read these as strings, so a field the form didn't fill in is stored as blank
+		additionalEcUrls := getSettingString(newSettings, fcTrueAdditionalExecutionClientUrls)
+		additionalCcUrls := getSettingString(newSettings, fcTrueAdditionalBeaconNodeUrls)
+		cfg.FallbackNormal.AdditionalEcHttpUrls.Value = additionalEcUrls
+		cfg.FallbackNormal.AdditionalCcHttpUrls.Value = additionalCcUrls
+		cfg.FallbackPrysm.AdditionalEcHttpUrls.Value = additionalEcUrls
+		cfg.FallbackPrysm.AdditionalCcHttpUrls.Value = additionalCcUrls
+	} else {
+		// The additional URLs are only used alongside the fallback clients
+		cfg.FallbackNormal.AdditionalEcHttpUrls.Value = ""
+		cfg.FallbackNormal.AdditionalCcHttpUrls.Value = ""
+		cfg.FallbackPrysm.AdditionalEcHttpUrls.Value = ""
+		cfg.FallbackPrysm.AdditionalCcHttpUrls.Value = ""
 	}
 	return nil
 }
+
+// Get a string setting from the UI, or a blank string if it isn't set
+func getSettingString(settings map[string]interface{}, key string) string {
+	value, ok := settings[key].(string)
+	if !ok {
+		return ""
+	}
+	return value
+}
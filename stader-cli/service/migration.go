@@ -173,15 +173,33 @@ func bumpConfigVersion(c *cli.Context) error {
 	return staderClient.SaveConfig(cfg)
 }
 
+// Rewrite the lodestar keystore from the wallet
 func upgradeFuncV30(c *cli.Context) error {
 	staderClient, err := stader.NewClientFromCtx(c)
 	if err != nil {
 		return fmt.Errorf("error NewClientFromCtx: %w", err)
 	}
 
-	_, err = staderClient.RebuildWallet()
+	// There's no lodestar keystore without a wallet, or when a Web3Signer holds the keys instead
+	cfg, _, err := staderClient.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error LoadConfig: %w", err)
+	}
+	if cfg.StaderNode.Web3SignerUrl.Value.(string) != "" {
+		return nil
+	}
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return fmt.Errorf("error WalletStatus: %w", err)
+	}
+	if !status.WalletInitialized {
+		return nil
+	}
+
+	// Write every key again, including the ones that already load
+	_, err = staderClient.RebuildKeystores("lodestar", true)
 	if err != nil {
-		return fmt.Errorf("error RebuildWallet: %w", err)
+		return fmt.Errorf("error RebuildKeystores: %w", err)
 	}
 
 	return nil
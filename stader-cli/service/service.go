@@ -695,8 +695,25 @@ func startService(
 		fmt.Printf("%sIgnoring anti-slashing safety delay.%s\n", colorYellow, colorReset)
 	}
 
-	// Write a note on doppelganger protection
+	// Make sure the validator keys aren't already live elsewhere before starting the validator client.
+	// The validator client's own Doppelganger Protection already does this, so there's no need to wait twice.
 	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
+	doppelgangerCheckEpochs := cfg.StaderNode.DoppelgangerCheckEpochs.Value.(uint64)
+	if c.Bool("ignore-doppelganger-check") || doppelgangerCheckEpochs == 0 {
+		fmt.Printf("%sSkipping the doppelganger check.%s\n", colorYellow, colorReset)
+	} else if err == nil && doppelgangerEnabled {
+		fmt.Println("Skipping the doppelganger check, your validator client will check for doppelgangers itself.")
+	} else {
+		safeToStart, err := checkForDoppelgangers(c, staderClient, doppelgangerCheckEpochs)
+		if err != nil {
+			return err
+		}
+		if !safeToStart {
+			return nil
+		}
+	}
+
+	// Write a note on doppelganger protection
 	if err != nil {
 		fmt.Printf("%sCouldn't check if you have Doppelganger Protection enabled: %s\nIf you do, your validator will miss up to 3 attestations when it starts.\nThis is *intentional* and does not indicate a problem with your node.%s\n\n", colorYellow, err.Error(), colorReset)
 	} else if doppelgangerEnabled {
@@ -716,6 +733,50 @@ func startService(
 
 }
 
+// Starts everything but the validator client and watches the validator keys for liveness; returns true if it's safe to start the validator client,
+// or an error if any of them are live elsewhere
+func checkForDoppelgangers(c *cli.Context, staderClient *stader.Client, epochs uint64) (bool, error) {
+
+	fmt.Println("Starting the Stader service without the validator client to check for doppelgangers...")
+	err := staderClient.StartServiceWithoutValidator(getComposeFiles(c))
+	if err != nil {
+		return false, err
+	}
+
+	// There are no keys to protect until the wallet is set up
+	walletStatus, err := staderClient.WalletStatus()
+	if err != nil {
+		return false, err
+	}
+	if !walletStatus.WalletInitialized {
+		return true, nil
+	}
+
+	fmt.Printf("Watching your validators for %d epoch(s) to make sure they aren't running anywhere else. This will take a few minutes...\n", epochs)
+	response, err := staderClient.CheckForDoppelgangers(epochs)
+	if err != nil {
+		fmt.Printf("%sWarning: couldn't check your validators for doppelgangers:\n\t%s%s\n", colorYellow, err.Error(), colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Do you want to start the validator client anyway?")) {
+			fmt.Println("The validator client was not started.")
+			return false, nil
+		}
+		return true, nil
+	}
+
+	if len(response.LiveValidators) > 0 {
+		fmt.Printf("%sThe following validators were seen attesting while your validator client was stopped:\n", colorRed)
+		for _, pubkey := range response.LiveValidators {
+			fmt.Printf("\t%s\n", pubkey)
+		}
+		fmt.Printf("They are already running on another machine, and running them here as well would get them slashed.%s\n", colorReset)
+		return false, fmt.Errorf("the validator client was not started because %d validator(s) are live elsewhere; stop the other machine, then run `stader-cli service start` again", len(response.LiveValidators))
+	}
+
+	fmt.Printf("%sNo doppelgangers found for your %d validator(s).%s\n\n", colorGreen, response.ValidatorsChecked, colorReset)
+	return true, nil
+
+}
+
 func checkForValidatorChange(stader *stader.Client, cfg *config.StaderConfig) error {
 
 	// Get the container prefix
@@ -48,6 +48,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "num-validators, nv",
 						Usage: "Number of validators you want to create (Required)",
 					},
+					cli.BoolFlag{
+						Name:  "imported-keys, ik",
+						Usage: "Register keys imported with 'stader-cli validator import-keystores' instead of creating new ones",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -130,6 +134,218 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return getValidatorStatus(c)
 				},
 			},
+			{
+				Name:      "duties",
+				Usage:     "List upcoming proposer and sync committee duties and suggest a duty-free maintenance window",
+				UsageText: "stader-cli validator duties [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "epochs, n",
+						Usage: "Number of upcoming epochs to check, including the current one. Proposers are only known for the current and next epoch, so more epochs only add sync committee duties, which are known until the end of the next sync committee period",
+						Value: 2,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate flags
+					if c.Uint64("epochs") == 0 {
+						return fmt.Errorf("epochs needs to be > 0")
+					}
+
+					// Run
+					return getValidatorDuties(c, c.Uint64("epochs"))
+				},
+			},
+			{
+				Name:      "reconcile",
+				Usage:     "Report drift between the validators on chain, the wallet, the keystores and the validator client, with a suggested fix for each",
+				UsageText: "stader-cli validator reconcile [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verify-keys, v",
+						Usage: "Also decrypt every local key to check the validator client can load it",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return reconcileValidatorKeys(c, c.Bool("verify-keys"))
+				},
+			},
+			{
+				Name:      "remove-key",
+				Usage:     "Remove the key of a validator with no more duties, or one that isn't registered, from the keystores and the validator client",
+				UsageText: "stader-cli validator remove-key --validator-pub-key",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "validator-pub-key, vpk",
+						Usage: "Public key of the validator whose key to remove",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the removal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate flags
+					validatorPubKey, err := cliutils.ValidatePubkey("validator-pub-key", c.String("validator-pub-key"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return removeValidatorKey(c, validatorPubKey)
+				},
+			},
+			{
+				Name:      "import-keystores",
+				Usage:     "Import externally generated EIP-2335 keystores so their keys can be registered as validators",
+				UsageText: "stader-cli validator import-keystores --dir",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "dir, d",
+						Usage: "Folder with the keystores, such as the validator_keys folder made by the staking deposit CLI (Required)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate flags
+					if c.String("dir") == "" {
+						return fmt.Errorf("dir needs to be set")
+					}
+
+					// Run
+					return importKeystores(c, c.String("dir"))
+				},
+			},
+			{
+				Name:  "graffiti",
+				Usage: "Manage the graffiti of each validator",
+				Subcommands: []cli.Command{
+					{
+						Name:      "set",
+						Aliases:   []string{"s"},
+						Usage:     "Set the graffiti template; it can include {operator}, {ec}, {cc}, {vc} and {index}",
+						UsageText: "stader-cli validator graffiti set template",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return setGraffitiTemplate(c, c.Args().Get(0))
+
+						},
+					},
+					{
+						Name:      "show",
+						Aliases:   []string{"sh"},
+						Usage:     "Show the graffiti template and the graffiti of each validator",
+						UsageText: "stader-cli validator graffiti show",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return showValidatorSettings(c)
+
+						},
+					},
+					{
+						Name:      "clear",
+						Aliases:   []string{"c"},
+						Usage:     "Clear the graffiti template so the validator client uses the Custom Graffiti",
+						UsageText: "stader-cli validator graffiti clear",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return setGraffitiTemplate(c, "")
+
+						},
+					},
+				},
+			},
+			{
+				Name:  "gas-limit",
+				Usage: "Manage the gas limit the validators register with builders",
+				Subcommands: []cli.Command{
+					{
+						Name:      "set",
+						Aliases:   []string{"s"},
+						Usage:     "Set the gas limit",
+						UsageText: "stader-cli validator gas-limit set gas-limit",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							gasLimit, err := cliutils.ValidatePositiveUint("gas limit", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return setValidatorGasLimit(c, gasLimit)
+
+						},
+					},
+					{
+						Name:      "show",
+						Aliases:   []string{"sh"},
+						Usage:     "Show the gas limit and the gas limit of each validator",
+						UsageText: "stader-cli validator gas-limit show",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return showValidatorSettings(c)
+
+						},
+					},
+					{
+						Name:      "clear",
+						Aliases:   []string{"c"},
+						Usage:     "Clear the gas limit so the validator client uses its default",
+						UsageText: "stader-cli validator gas-limit clear",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return setValidatorGasLimit(c, 0)
+
+						},
+					},
+				},
+			},
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -29,6 +29,7 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	numValidators := c.Uint64("num-validators")
+	useImportedKeys := c.Bool("imported-keys")
 
 	baseAmountInEth := 4
 	baseAmount := eth.EthToWei(4.0)
@@ -52,7 +53,7 @@ func nodeDeposit(c *cli.Context) error {
 		}
 	}
 
-	canNodeDepositResponse, err := staderClient.CanNodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true)
+	canNodeDepositResponse, err := staderClient.CanNodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true, useImportedKeys)
 	if err != nil {
 		return err
 	}
@@ -76,6 +77,10 @@ func nodeDeposit(c *cli.Context) error {
 		fmt.Printf("You can only add %d keys at a time\n", canNodeDepositResponse.InputKeyLimit)
 		return nil
 	}
+	if canNodeDepositResponse.NotEnoughImportedKeys {
+		fmt.Printf("Only %d of your imported keys haven't been used for a validator yet. Import more with `stader-cli validator import-keystores`.\n", canNodeDepositResponse.UnusedImportedKeys)
+		return nil
+	}
 
 	//Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canNodeDepositResponse.GasInfo, staderClient, c.Bool("yes"))
@@ -95,7 +100,7 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Make deposit
-	response, err := staderClient.NodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true)
+	response, err := staderClient.NodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true, useImportedKeys)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+const dutyTimeFormat = "2006-01-02 15:04:05 MST"
+
+func getValidatorDuties(c *cli.Context, epochs uint64) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Get the upcoming duties
+	response, err := staderClient.GetValidatorDuties(epochs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Checked %d validator(s) from epoch %d to epoch %d.\n", response.ActiveValidators, response.CurrentEpoch, response.EndEpoch)
+	if response.EndEpoch < response.CurrentEpoch+epochs-1 {
+		fmt.Printf("%sNOTE: No duties are known past epoch %d yet, so later epochs weren't checked.%s\n", log.ColorYellow, response.EndEpoch, log.ColorReset)
+	}
+	fmt.Println()
+	if response.ActiveValidators == 0 {
+		fmt.Println("The node has no validators on the Beacon chain, so there are no upcoming duties.")
+		return nil
+	}
+
+	fmt.Printf("%s=== Proposer Duties ===%s\n", log.ColorGreen, log.ColorReset)
+	if len(response.ProposerDuties) == 0 {
+		fmt.Println("No upcoming block proposals.")
+	}
+	for _, duty := range response.ProposerDuties {
+		fmt.Printf("-Slot %d (epoch %d) at %s: validator %d (%s)\n", duty.Slot, duty.Epoch, duty.Time.Local().Format(dutyTimeFormat), duty.Index, duty.Pubkey)
+	}
+	if response.ProposerLookaheadEpoch < response.EndEpoch {
+		fmt.Printf("%sNOTE: Block proposers are only known up to epoch %d; later proposals can't be predicted yet.%s\n", log.ColorYellow, response.ProposerLookaheadEpoch, log.ColorReset)
+	}
+	fmt.Println()
+
+	fmt.Printf("%s=== Sync Committee Duties ===%s\n", log.ColorGreen, log.ColorReset)
+	if len(response.SyncDuties) == 0 {
+		fmt.Println("No upcoming sync committee duties.")
+	}
+	for _, duty := range response.SyncDuties {
+		fmt.Printf("-Period %d (epochs %d - %d) from %s to %s: validator %d (%s)\n", duty.Period, duty.StartEpoch, duty.EndEpoch, duty.StartTime.Local().Format(dutyTimeFormat), duty.EndTime.Local().Format(dutyTimeFormat), duty.Index, duty.Pubkey)
+	}
+	fmt.Println()
+
+	fmt.Printf("%s=== Suggested Maintenance Window ===%s\n", log.ColorGreen, log.ColorReset)
+	if response.DutyFreeWindow == nil {
+		fmt.Printf("There is no duty-free window up to epoch %d, the last one with known duties.\n", response.ProposerLookaheadEpoch)
+		return nil
+	}
+	window := response.DutyFreeWindow
+	fmt.Printf("Epochs %d - %d, from %s to %s (%s).\n", window.StartEpoch, window.EndEpoch, window.StartTime.Local().Format(dutyTimeFormat), window.EndTime.Local().Format(dutyTimeFormat), window.EndTime.Sub(window.StartTime).Round(time.Minute))
+	fmt.Println("Your validators will still miss attestations while they are offline during this window.")
+	if response.ProposerLookaheadEpoch < response.EndEpoch {
+		fmt.Printf("Duties after epoch %d aren't known yet, so the window can't be checked past it. Run this again closer to your maintenance to find a later window.\n", response.ProposerLookaheadEpoch)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// A keystore found in the import folder
+type keystoreFile struct {
+	name   string
+	bytes  []byte
+	pubkey types.ValidatorPubkey
+}
+
+func importKeystores(c *cli.Context, dir string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	cfg, isNew, err := staderClient.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `stader-cli service config` to set up your Stader Node before importing keystores.")
+	}
+
+	// Find the keystores, skipping other files such as the deposit data
+	keystores, err := readKeystoreFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(keystores) == 0 {
+		fmt.Printf("No EIP-2335 keystores were found in %s.\n", dir)
+		return nil
+	}
+	fmt.Printf("Found %d keystores:\n", len(keystores))
+	for _, keystore := range keystores {
+		fmt.Printf("\t%s (%s)\n", keystore.name, keystore.pubkey.Hex())
+	}
+	fmt.Println()
+
+	// Prompt for confirmation
+	fmt.Printf("%sWARNING: If any of these keys is running in another validator client, or ever will be, running it here as well WILL RESULT IN YOUR VALIDATOR BEING SLASHED.%s\n\n", log.ColorRed, log.ColorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure these keys aren't running anywhere else, and do you want to import them?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	password := cliutils.PromptPassword("Please enter the password the keystores were encrypted with:", "^.*$", "")
+
+	// Stage the keystores and their passwords where the daemon can read them
+	datapath, err := homedir.Expand(cfg.StaderNode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+	customKeyDir := filepath.Join(datapath, "custom-keys")
+	if err := os.MkdirAll(customKeyDir, 0775); err != nil {
+		return fmt.Errorf("error creating custom keystore directory: %w", err)
+	}
+	passwordFile := filepath.Join(datapath, "custom-key-passwords")
+	defer os.Remove(passwordFile)
+	pubkeyPasswords := map[string]string{}
+	for _, keystore := range keystores {
+		stagedPath := filepath.Join(customKeyDir, keystore.name)
+		defer os.Remove(stagedPath)
+		if err := ioutil.WriteFile(stagedPath, keystore.bytes, 0600); err != nil {
+			return fmt.Errorf("error staging keystore %s: %w", keystore.name, err)
+		}
+		pubkeyPasswords[strings.ToUpper(hexutils.RemovePrefix(keystore.pubkey.Hex()))] = password
+	}
+	fileBytes, err := yaml.Marshal(pubkeyPasswords)
+	if err != nil {
+		return fmt.Errorf("error serializing keystore passwords file: %w", err)
+	}
+	if err := ioutil.WriteFile(passwordFile, fileBytes, 0600); err != nil {
+		return fmt.Errorf("error writing keystore passwords file: %w", err)
+	}
+
+	// Import them
+	fmt.Println("Decrypting and importing the keystores, this may take a while...")
+	response, err := staderClient.ImportKeystores()
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for _, keystore := range response.Keystores {
+		switch keystore.Result {
+		case api.ImportedKeystore_Imported:
+			imported++
+			fmt.Printf("%sImported%s %s\n", log.ColorGreen, log.ColorReset, keystore.Pubkey.Hex())
+		case api.ImportedKeystore_AlreadyImported:
+			imported++
+			fmt.Printf("Already imported %s; it was stored in the keystores again\n", keystore.Pubkey.Hex())
+		case api.ImportedKeystore_InUse:
+			fmt.Printf("%sSkipped%s %s: %s\n", log.ColorYellow, log.ColorReset, keystore.Pubkey.Hex(), keystore.Detail)
+		}
+	}
+	if imported > 0 {
+		fmt.Printf("\nRegister the imported keys as validators with `stader-cli validator deposit --imported-keys --num-validators <count>`.\n")
+	}
+	return nil
+
+}
+
+// Read the EIP-2335 keystores in a folder
+func readKeystoreFiles(dir string) ([]keystoreFile, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", dir, err)
+	}
+
+	keystores := []keystoreFile{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		bytes, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file.Name(), err)
+		}
+		var keystore struct {
+			Crypto map[string]interface{} `json:"crypto"`
+			Pubkey string                 `json:"pubkey"`
+		}
+		if err := json.Unmarshal(bytes, &keystore); err != nil || keystore.Crypto == nil {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(hexutils.RemovePrefix(keystore.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("keystore %s has an invalid pubkey: %w", file.Name(), err)
+		}
+		keystores = append(keystores, keystoreFile{
+			name:   file.Name(),
+			bytes:  bytes,
+			pubkey: pubkey,
+		})
+	}
+	return keystores, nil
+}
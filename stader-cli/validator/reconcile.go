@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+	"github.com/urfave/cli"
+)
+
+// Headings for each kind of finding, in the order they're shown
+var validatorKeyFindingHeadings = []struct {
+	kind    string
+	heading string
+}{
+	{api.ValidatorKeyFinding_MissingLocally, "Registered validators missing locally"},
+	{api.ValidatorKeyFinding_Unloadable, "Keys the validator client can't load"},
+	{api.ValidatorKeyFinding_NextAccountBehind, "Registered keys past the wallet's next index"},
+	{api.ValidatorKeyFinding_Unregistered, "Local keys not registered with your operator"},
+	{api.ValidatorKeyFinding_TerminalLoaded, "Finished validators still loaded"},
+	{api.ValidatorKeyFinding_IndexGap, "Unregistered wallet indices"},
+}
+
+func reconcileValidatorKeys(c *cli.Context, verifyKeys bool) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	if verifyKeys {
+		fmt.Println("Decrypting every validator key to verify it, this may take a while...")
+	}
+	response, err := staderClient.ReconcileValidatorKeys(verifyKeys)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Registered validators: %d\n", response.RegisteredValidators)
+	fmt.Printf("Keys in the %s keystore: %d\n", response.Keystore, response.LocalValidators)
+	if response.KeymanagerUnavailable {
+		fmt.Printf("%sThe validator client's keymanager API isn't available, so its keystore is assumed to be what it has loaded.%s\n", log.ColorYellow, log.ColorReset)
+	} else {
+		fmt.Printf("Keys loaded in the validator client: %d\n", response.LoadedValidators)
+	}
+	fmt.Printf("Wallet's next validator index: %d\n\n", response.NextAccount)
+
+	if len(response.Findings) == 0 {
+		fmt.Printf("%sThe wallet, the keystores, the validator client and the chain all agree.%s\n", log.ColorGreen, log.ColorReset)
+		return nil
+	}
+
+	for _, kind := range validatorKeyFindingHeadings {
+		findings := []api.ValidatorKeyFinding{}
+		for _, finding := range response.Findings {
+			if finding.Kind == kind.kind {
+				findings = append(findings, finding)
+			}
+		}
+		if len(findings) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s=== %s (%d) ===%s\n", log.ColorYellow, kind.heading, len(findings), log.ColorReset)
+		for _, finding := range findings {
+			if finding.Pubkey != (types.ValidatorPubkey{}) {
+				fmt.Printf("%s\n", finding.Pubkey.Hex())
+			}
+			fmt.Printf("\t%s\n", finding.Detail)
+			fmt.Printf("\t%sFix:%s %s\n", log.ColorGreen, log.ColorReset, finding.SuggestedFix)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+func removeValidatorKey(c *cli.Context, validatorPubKey types.ValidatorPubkey) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+		"Are you sure you want to remove the key of validator %s from the keystores and the validator client? Make sure you have a backup of it if you'll ever need it again.", validatorPubKey))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	response, err := staderClient.RemoveValidatorKey(validatorPubKey)
+	if err != nil {
+		return err
+	}
+	if response.ValidatorActive {
+		fmt.Printf("Validator %s is registered with your operator and still has duties, so its key can't be removed. Exit it first with `stader-cli validator exit-validator`.\n", validatorPubKey)
+		return nil
+	}
+
+	fmt.Printf("Removed the key of validator %s.\n", validatorPubKey)
+	return nil
+
+}
@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/urfave/cli"
+)
+
+func setGraffitiTemplate(c *cli.Context, template string) error {
+	if err := validator.ValidateGraffitiTemplate(template); err != nil {
+		return err
+	}
+	return updateValidatorSettings(c, func(cfg *config.StaderConfig) {
+		cfg.StaderNode.GraffitiTemplate.Value = template
+	})
+}
+
+func setValidatorGasLimit(c *cli.Context, gasLimit uint64) error {
+	err := updateValidatorSettings(c, func(cfg *config.StaderConfig) {
+		cfg.StaderNode.ValidatorGasLimit.Value = gasLimit
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sNOTE: The validator client's default gas limit only changes after you restart it with `stader-cli service start`.%s\n", log.ColorYellow, log.ColorReset)
+	return nil
+}
+
+// Save a change to the validator settings and apply it to the validators right away
+func updateValidatorSettings(c *cli.Context, update func(cfg *config.StaderConfig)) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	cfg, isNew, err := staderClient.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `stader-cli service config` to set up your Stader Node before changing validator settings.")
+	}
+	oldGraffitiTemplate := cfg.StaderNode.GraffitiTemplate.Value.(string)
+	oldGasLimit := cfg.StaderNode.ValidatorGasLimit.Value.(uint64)
+	update(cfg)
+	if err := staderClient.SaveConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Println("Saved the validator settings.")
+
+	// Only remove the overrides of a setting that was just cleared, so ones set outside of Stader are left alone
+	clearGraffiti := oldGraffitiTemplate != "" && cfg.StaderNode.GraffitiTemplate.Value.(string) == ""
+	clearGasLimit := oldGasLimit != 0 && cfg.StaderNode.ValidatorGasLimit.Value.(uint64) == 0
+	response, err := staderClient.ApplyValidatorSettings(clearGraffiti, clearGasLimit)
+	if err != nil {
+		return err
+	}
+	printValidatorSettings(response)
+	return nil
+
+}
+
+func showValidatorSettings(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	response, err := staderClient.GetValidatorSettings()
+	if err != nil {
+		return err
+	}
+	printValidatorSettings(response)
+	return nil
+
+}
+
+func printValidatorSettings(response api.ValidatorSettingsResponse) {
+
+	if response.GraffitiTemplate == "" {
+		fmt.Println("Graffiti template: none (the validator client uses the Custom Graffiti)")
+	} else {
+		fmt.Printf("Graffiti template: %s\n", response.GraffitiTemplate)
+	}
+	if response.GasLimit == 0 {
+		fmt.Println("Gas limit: none (the validator client uses its default)")
+	} else {
+		fmt.Printf("Gas limit: %d\n", response.GasLimit)
+	}
+	fmt.Println()
+
+	if response.KeymanagerUnavailable {
+		fmt.Printf("%sThe validator client's keymanager API isn't available, so these settings can't be applied to each validator. Enable it in `stader-cli service config` and make sure the validator client is running.%s\n", log.ColorYellow, log.ColorReset)
+		fmt.Println("Without it, the validator client uses the graffiti template (without the {index}) and the gas limit for every validator after you restart it with `stader-cli service start`.")
+		return
+	}
+	if len(response.Validators) == 0 {
+		fmt.Println("The validator client has no active validators of this node loaded.")
+		return
+	}
+
+	for _, settings := range response.Validators {
+		fmt.Printf("Validator %s:\n", settings.Pubkey.Hex())
+		if response.GraffitiTemplate != "" {
+			printValidatorSetting("graffiti", fmt.Sprintf("%q", settings.Graffiti), fmt.Sprintf("%q", settings.DesiredGraffiti), settings.Graffiti == settings.DesiredGraffiti, settings.Updated)
+		}
+		if response.GasLimit != 0 {
+			printValidatorSetting("gas limit", fmt.Sprint(settings.GasLimit), fmt.Sprint(settings.DesiredGasLimit), settings.GasLimit == settings.DesiredGasLimit, settings.Updated)
+		}
+	}
+
+}
+
+func printValidatorSetting(name string, value string, desiredValue string, matches bool, updated bool) {
+	switch {
+	case matches && updated:
+		fmt.Printf("\t%s: %s (updated)\n", name, value)
+	case matches:
+		fmt.Printf("\t%s: %s\n", name, value)
+	default:
+		fmt.Printf("\t%s: %s%s (will be set to %s)%s\n", name, log.ColorYellow, value, desiredValue, log.ColorReset)
+	}
+}
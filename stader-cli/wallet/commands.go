@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package wallet
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
@@ -147,6 +149,84 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a node account transaction exported with --offline-export; run this on the offline machine that holds the node wallet",
+				UsageText: "stader-cli wallet sign-tx [options] unsigned-tx-file",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the signed transaction to; defaults to the file it was read from",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return signTransaction(c, c.Args().Get(0))
+
+				},
+			},
+			{
+				Name:      "set-next-index",
+				Usage:     "Move the wallet index the next validator key is derived from forward, past keys that were registered without this wallet",
+				UsageText: "stader-cli wallet set-next-index [options] index",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm moving the index",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return setNextValidatorIndex(c, index)
+
+				},
+			},
+			{
+				Name:      "rebuild-keystores",
+				Usage:     "Rebuild a consensus client's keystore from the wallet's mnemonic and imported validator keys, such as after switching clients",
+				UsageText: "stader-cli wallet rebuild-keystores --client [--force]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "client, c",
+						Usage: "The keystore to rebuild: lighthouse, lodestar, nimbus, prysm, teku or web3signer (Required)",
+					},
+					cli.BoolFlag{
+						Name:  "force, f",
+						Usage: "Write every key again, even the ones the keystore can already load",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate flags
+					if c.String("client") == "" {
+						return fmt.Errorf("client needs to be set")
+					}
+
+					// Run
+					return rebuildKeystores(c, c.String("client"), c.Bool("force"))
+
+				},
+			},
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
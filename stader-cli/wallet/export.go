@@ -71,7 +71,11 @@ func exportWallet(c *cli.Context) error {
 	// Print wallet & return
 	fmt.Println("Node account private key:")
 	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
+	if export.AccountPrivateKey == "" {
+		fmt.Println("(held by the external signer)")
+	} else {
+		fmt.Println(export.AccountPrivateKey)
+	}
 	fmt.Println("")
 	fmt.Println("Wallet password:")
 	fmt.Println("")
@@ -0,0 +1,46 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+func rebuildKeystores(c *cli.Context, client string, force bool) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get & check wallet status
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Rebuild the keystore
+	fmt.Printf("Rebuilding the %s keystore and verifying every key, this may take a while...\n", client)
+	response, err := staderClient.RebuildKeystores(client, force)
+	if err != nil {
+		return err
+	}
+
+	for _, pubkey := range response.RebuiltKeys {
+		fmt.Printf("%sRebuilt%s %s\n", log.ColorGreen, log.ColorReset, pubkey.Hex())
+	}
+	fmt.Printf("\nRebuilt %d keys in the %s keystore; %d keys were already there and loadable.\n", len(response.RebuiltKeys), client, len(response.UnchangedKeys))
+	if response.ActiveKeystore && len(response.RebuiltKeys) > 0 {
+		fmt.Printf("%sThis is your validator client's keystore. Restart it with `stader-cli service start` so it loads the rebuilt keys.%s\n", log.ColorYellow, log.ColorReset)
+	}
+	return nil
+
+}
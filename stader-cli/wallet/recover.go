@@ -115,6 +115,7 @@ func recoverWallet(c *cli.Context) error {
 				for _, key := range response.ValidatorKeys {
 					fmt.Println(key.Hex())
 				}
+				fmt.Printf("%sThe validator client doesn't load recovered keys until it restarts. Make sure they aren't running on any other machine, then run `stader-cli service start` so they're checked for doppelgangers before they're loaded.%s\n", log.ColorYellow, log.ColorReset)
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
@@ -169,6 +170,7 @@ func recoverWallet(c *cli.Context) error {
 				for _, key := range response.ValidatorKeys {
 					fmt.Println(key.Hex())
 				}
+				fmt.Printf("%sThe validator client doesn't load recovered keys until it restarts. Make sure they aren't running on any other machine, then run `stader-cli service start` so they're checked for doppelgangers before they're loaded.%s\n", log.ColorYellow, log.ColorReset)
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+func setNextValidatorIndex(c *cli.Context, index uint64) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get & check wallet status
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Your next validator key will be derived from wallet index %d. The index can't be moved back afterwards. Do you want to continue?", index))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	response, err := staderClient.SetNextValidatorIndex(index)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Moved the next validator key index from %d to %d.\n", response.PreviousIndex, index)
+	return nil
+
+}
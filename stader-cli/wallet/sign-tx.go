@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/math"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+func signTransaction(c *cli.Context, path string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Load the exported transaction
+	offlineTx, err := wallet.LoadOfflineTransaction(path)
+	if err != nil {
+		return err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(offlineTx.UnsignedTx); err != nil {
+		return fmt.Errorf("Could not decode the unsigned transaction in %s: %w", path, err)
+	}
+
+	// Show what's being signed, since this is the last chance to check it
+	fmt.Printf("Transaction exported by `%s`:\n", offlineTx.Action)
+	fmt.Printf("\tFrom:     %s\n", offlineTx.From.Hex())
+	if tx.To() != nil {
+		fmt.Printf("\tTo:       %s\n", tx.To().Hex())
+	}
+	fmt.Printf("\tValue:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(tx.Value()), 6))
+	fmt.Printf("\tData:     %d bytes\n", len(tx.Data()))
+	fmt.Printf("\tChain ID: %s\n", tx.ChainId())
+	fmt.Printf("\tNonce:    %d\n", tx.Nonce())
+	fmt.Printf("\tMax fee:  %.6f gwei (%.6f gwei priority fee), gas limit %d\n", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(tx.GasTipCap()), tx.Gas())
+	maxTxFee := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+	fmt.Printf("\tFees of up to %.6f ETH\n\n", math.RoundDown(eth.WeiToEth(maxTxFee), 6))
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to sign this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign it
+	response, err := staderClient.SignTransaction(offlineTx.UnsignedTx, offlineTx.From)
+	if err != nil {
+		return err
+	}
+
+	// Save it
+	offlineTx.SignedTx = response.SignedTx
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = path
+	}
+	if err := offlineTx.Save(outputPath); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The signed transaction was written to %s.\n", outputPath)
+	fmt.Println("Copy it back to your node and send it with `stader-cli node broadcast`.")
+	return nil
+
+}
@@ -52,6 +52,9 @@ type Contract struct {
 type GasInfo struct {
 	EstGasLimit  uint64 `json:"estGasLimit"`
 	SafeGasLimit uint64 `json:"safeGasLimit"`
+
+	// Why the transaction would revert if it were sent now; empty if the simulation succeeded
+	SimulationError string `json:"simulationError,omitempty"`
 }
 
 // Call a contract method
@@ -72,9 +75,13 @@ func (c *Contract) GetTransactionGasInfo(opts *bind.TransactOpts, method string,
 		return response, fmt.Errorf("Error getting transaction gas info: Could not encode input data: %w", err)
 	}
 
-	// Estimate gas limit
+	// Estimate gas limit, explaining a revert rather than failing
 	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, input)
-
+	var revertErr *gasRevertError
+	if errors.As(err, &revertErr) {
+		response.SimulationError = revertErr.reason
+		return response, nil
+	}
 	if err != nil {
 		return response, fmt.Errorf("Error getting transaction gas info: could not estimate gas limit: %w", err)
 	}
@@ -116,8 +123,13 @@ func (c *Contract) GetTransferGasInfo(opts *bind.TransactOpts) (GasInfo, error)
 
 	response := GasInfo{}
 
-	// Estimate gas limit
+	// Estimate gas limit, explaining a revert rather than failing
 	estGasLimit, safeGasLimit, err := c.estimateGasLimit(opts, []byte{})
+	var revertErr *gasRevertError
+	if errors.As(err, &revertErr) {
+		response.SimulationError = revertErr.reason
+		return response, nil
+	}
 	if err != nil {
 		return response, fmt.Errorf("Error getting transfer gas info: could not estimate gas limit: %w", err)
 	}
@@ -149,6 +161,15 @@ func (c *Contract) Transfer(opts *bind.TransactOpts) (common.Hash, error) {
 
 }
 
+// The error from estimating the gas of a transaction that would revert
+type gasRevertError struct {
+	reason string
+}
+
+func (e *gasRevertError) Error() string {
+	return fmt.Sprintf("transaction would revert: %s", e.reason)
+}
+
 // Estimate the expected and safe gas limits for a contract transaction
 func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint64, uint64, error) {
 
@@ -162,6 +183,9 @@ func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint
 	})
 
 	if err != nil {
+		if reason, isRevert := DecodeRevert(err, c.ABI); isRevert {
+			return 0, 0, fmt.Errorf("Could not estimate gas needed: %w", &gasRevertError{reason: reason})
+		}
 		return 0, 0, fmt.Errorf("Could not estimate gas needed: %w", err)
 	}
 
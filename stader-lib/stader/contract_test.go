@@ -0,0 +1,76 @@
+package stader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// An execution client that answers gas estimates and fails every other call
+type estimateClient struct {
+	ExecutionClient
+	gas       uint64
+	err       error
+	estimates int
+}
+
+func (c *estimateClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	c.estimates++
+	return c.gas, c.err
+}
+
+func TestGetTransactionGasInfo(t *testing.T) {
+
+	contractAbi, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"deposit","inputs":[],"outputs":[]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                    string
+		gas                     uint64
+		err                     error
+		expectedError           bool
+		expectedSimulationError string
+		expectedSafeGasLimit    uint64
+	}{
+		{"estimated", 100000, nil, false, "", 150000},
+		{"capped at the max gas limit", MaxGasLimit - 1, nil, false, "", MaxGasLimit},
+		{"over the max gas limit", MaxGasLimit + 1, nil, true, "", 0},
+		{"revert is explained", 0, revertError{"execution reverted", encodeRevert(t, "Error(string)", []string{"string"}, "Pausable: paused")}, false, "Pausable: paused", 0},
+		{"other errors fail", 0, errors.New("connection refused"), true, "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &estimateClient{gas: test.gas, err: test.err}
+			contract := &Contract{Address: &common.Address{}, ABI: &contractAbi, Client: client}
+
+			for name, getGasInfo := range map[string]func() (GasInfo, error){
+				"transaction": func() (GasInfo, error) { return contract.GetTransactionGasInfo(&bind.TransactOpts{}, "deposit") },
+				"transfer":    func() (GasInfo, error) { return contract.GetTransferGasInfo(&bind.TransactOpts{}) },
+			} {
+				client.estimates = 0
+				gasInfo, err := getGasInfo()
+				if (err != nil) != test.expectedError {
+					t.Fatalf("%s: got %v, expected an error: %t", name, err, test.expectedError)
+				}
+				if gasInfo.SimulationError != test.expectedSimulationError || gasInfo.SafeGasLimit != test.expectedSafeGasLimit {
+					t.Fatalf("%s: unexpected gas info %+v", name, gasInfo)
+				}
+
+				// The estimate is the only call made, so a revert doesn't cost a separate eth_call
+				if client.estimates != 1 {
+					t.Fatalf("%s: made %d gas estimates, expected 1", name, client.estimates)
+				}
+			}
+		})
+	}
+
+}
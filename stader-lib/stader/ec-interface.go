@@ -68,6 +68,10 @@ type ExecutionClient interface {
 	// a timely execution of a transaction.
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 
+	// FeeHistory retrieves the base fees and the given priority fee percentiles of the
+	// blockCount blocks up to and including lastBlock. If lastBlock is nil, the latest block is used.
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+
 	// EstimateGas tries to estimate the gas needed to execute a specific
 	// transaction based on the current pending state of the backend blockchain.
 	// There is no guarantee that this is the true gas limit requirement as other
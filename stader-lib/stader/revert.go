@@ -0,0 +1,149 @@
+package stader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+)
+
+// Selectors of the revert types built into Solidity
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// Descriptions of the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop from an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// The ABIs of every Stader contract, used to decode custom errors raised by contracts other than the one that was called
+var (
+	knownAbis     []*abi.ABI
+	knownAbisOnce sync.Once
+)
+
+// Decode the revert data in an error from eth_call or eth_estimateGas into a readable message.
+// The ABI of the called contract is checked first for custom errors; it may be nil.
+// Returns false if the error isn't a revert or the revert data can't be decoded.
+func DecodeRevert(err error, contractAbi *abi.ABI) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	// Get the revert data
+	var dataErr rpc.DataError
+	dataString := ""
+	if errors.As(err, &dataErr) {
+		dataString, _ = dataErr.ErrorData().(string)
+	}
+	if dataString == "" {
+		// Some clients only include the reason in the message
+		if strings.Contains(err.Error(), "execution reverted") {
+			return err.Error(), true
+		}
+		return "", false
+	}
+	data, decodeErr := hexutil.Decode(dataString)
+	if decodeErr != nil {
+		return "", false
+	}
+	if len(data) < 4 {
+		return "reverted without a reason", true
+	}
+	selector := data[:4]
+
+	// Check the built-in revert types
+	if bytes.Equal(selector, errorSelector) {
+		reason, unpackErr := abi.UnpackRevert(data)
+		if unpackErr != nil {
+			return "", false
+		}
+		return reason, true
+	}
+	if bytes.Equal(selector, panicSelector) && len(data) == 36 {
+		code := new(big.Int).SetBytes(data[4:])
+		if reason, exists := panicReasons[code.Uint64()]; exists && code.IsUint64() {
+			return fmt.Sprintf("panic: %s", reason), true
+		}
+		return fmt.Sprintf("panic code 0x%x", code), true
+	}
+
+	// Check the custom errors of the called contract, then of every other contract
+	abis := getKnownAbis()
+	if contractAbi != nil {
+		abis = append([]*abi.ABI{contractAbi}, abis...)
+	}
+	for _, candidate := range abis {
+		for _, abiError := range candidate.Errors {
+			if !bytes.Equal(selector, abiError.ID[:4]) {
+				continue
+			}
+			return formatCustomError(abiError, data), true
+		}
+	}
+	return fmt.Sprintf("reverted with unknown error 0x%x", selector), true
+}
+
+// Format a custom error as Name(arg1, arg2...)
+func formatCustomError(abiError abi.Error, data []byte) string {
+	values, err := abiError.Unpack(data)
+	if err != nil {
+		return abiError.Name
+	}
+	args, ok := values.([]interface{})
+	if !ok || len(args) == 0 {
+		return abiError.Name
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprint(arg)
+	}
+	return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(formattedArgs, ", "))
+}
+
+// Parse the ABIs of the Stader contracts
+func getKnownAbis() []*abi.ABI {
+	knownAbisOnce.Do(func() {
+		for _, metaData := range []string{
+			contracts.NodeElRewardVaultMetaData.ABI,
+			contracts.OperatorRewardsCollectorMetaData.ABI,
+			contracts.PenaltyTrackerMetaData.ABI,
+			contracts.PermissionlessNodeRegistryMetaData.ABI,
+			contracts.PermissionlessPoolMetaData.ABI,
+			contracts.PoolUtilsMetaData.ABI,
+			contracts.SdCollateralMetaData.ABI,
+			contracts.SocializingPoolMetaData.ABI,
+			contracts.StaderConfigMetaData.ABI,
+			contracts.StakePoolManagerMetaData.ABI,
+			contracts.ValidatorWithdrawVaultMetaData.ABI,
+			contracts.VaultFactoryMetaData.ABI,
+			contracts.VaultProxyMetaData.ABI,
+		} {
+			parsed, err := abi.JSON(strings.NewReader(metaData))
+			if err != nil {
+				continue
+			}
+			knownAbis = append(knownAbis, &parsed)
+		}
+	})
+	return append([]*abi.ABI{}, knownAbis...)
+}
@@ -0,0 +1,131 @@
+package stader
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// An eth_call error with revert data, as the RPC client returns it
+type revertError struct {
+	message string
+	data    interface{}
+}
+
+func (e revertError) Error() string {
+	return e.message
+}
+
+func (e revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// Revert data for an error with the given signature and ABI-encoded arguments
+func encodeRevert(t *testing.T, signature string, types []string, values ...interface{}) string {
+	arguments := abi.Arguments{}
+	for _, typeName := range types {
+		argumentType, err := abi.NewType(typeName, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arguments = append(arguments, abi.Argument{Type: argumentType})
+	}
+	packed, err := arguments.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(append(crypto.Keccak256([]byte(signature))[:4], packed...))
+}
+
+func TestDecodeRevert(t *testing.T) {
+
+	calledAbi, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"OperatorNotActive","inputs":[{"name":"operatorId","type":"uint256"},{"name":"operator","type":"address"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	operator := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	tests := []struct {
+		name        string
+		err         error
+		contractAbi *abi.ABI
+		wantMessage string
+		wantOk      bool
+	}{
+		{
+			name:        "Error(string)",
+			err:         revertError{"execution reverted", encodeRevert(t, "Error(string)", []string{"string"}, "Ownable: caller is not the owner")},
+			wantMessage: "Ownable: caller is not the owner",
+			wantOk:      true,
+		},
+		{
+			name:        "Panic(uint256) with a known code",
+			err:         revertError{"execution reverted", encodeRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11))},
+			wantMessage: "panic: arithmetic overflow or underflow",
+			wantOk:      true,
+		},
+		{
+			name:        "Panic(uint256) with an unknown code",
+			err:         revertError{"execution reverted", encodeRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x99))},
+			wantMessage: "panic code 0x99",
+			wantOk:      true,
+		},
+		{
+			name:        "custom error from the called contract",
+			err:         revertError{"execution reverted", encodeRevert(t, "OperatorNotActive(uint256,address)", []string{"uint256", "address"}, big.NewInt(7), operator)},
+			contractAbi: &calledAbi,
+			wantMessage: "OperatorNotActive(7, " + operator.Hex() + ")",
+			wantOk:      true,
+		},
+		{
+			name:        "custom error from another Stader contract",
+			err:         revertError{"execution reverted", encodeRevert(t, "InsufficientSDToWithdraw(uint256)", []string{"uint256"}, big.NewInt(1000))},
+			contractAbi: &calledAbi,
+			wantMessage: "InsufficientSDToWithdraw(1000)",
+			wantOk:      true,
+		},
+		{
+			name:        "unknown selector",
+			err:         revertError{"execution reverted", "0xdeadbeef"},
+			contractAbi: &calledAbi,
+			wantMessage: "reverted with unknown error 0xdeadbeef",
+			wantOk:      true,
+		},
+		{
+			name:        "empty revert data",
+			err:         revertError{"execution reverted", "0x"},
+			wantMessage: "reverted without a reason",
+			wantOk:      true,
+		},
+		{
+			name:        "message only",
+			err:         errors.New("execution reverted: not enough collateral"),
+			wantMessage: "execution reverted: not enough collateral",
+			wantOk:      true,
+		},
+		{
+			name:   "not a revert",
+			err:    errors.New("connection refused"),
+			wantOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, ok := DecodeRevert(test.err, test.contractAbi)
+			if ok != test.wantOk {
+				t.Fatalf("got ok %t, expected %t", ok, test.wantOk)
+			}
+			if message != test.wantMessage {
+				t.Fatalf("got %q, expected %q", message, test.wantMessage)
+			}
+		})
+	}
+
+}
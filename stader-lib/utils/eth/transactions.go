@@ -41,16 +41,22 @@ func EstimateSendTransactionGas(client stader.ExecutionClient, toAddress common.
 	if value == nil {
 		value = big.NewInt(0)
 	}
-
-	// Estimate gas limit
-	gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+	msg := ethereum.CallMsg{
 		From:     opts.From,
 		To:       &toAddress,
 		GasPrice: big.NewInt(0), // set to 0 for simulation
 		Value:    value,
-	})
+	}
+
+	// Estimate gas limit, explaining a revert rather than failing
+	gasLimit, err := client.EstimateGas(context.Background(), msg)
 	if err != nil {
-		return stader.GasInfo{}, err
+		reason, isRevert := stader.DecodeRevert(err, nil)
+		if !isRevert {
+			return stader.GasInfo{}, err
+		}
+		response.SimulationError = reason
+		return response, nil
 	}
 	response.EstGasLimit = gasLimit
 	response.SafeGasLimit = gasLimit
@@ -113,6 +119,9 @@ func SendTransaction(client stader.ExecutionClient, toAddress common.Address, ch
 	}
 
 	// Send transaction
+	if opts.NoSend {
+		return signedTx.Hash(), nil
+	}
 	if err = client.SendTransaction(context.Background(), signedTx); err != nil {
 		return common.Hash{}, err
 	}
@@ -25,47 +25,119 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
 )
 
-// Wait for a transaction to get mined
-func WaitForTransaction(client stader.ExecutionClient, hash common.Hash) (*types.Receipt, error) {
+// How often the client is polled while waiting for a transaction
+var waitPollInterval = 2 * time.Second
 
-	var tx *types.Transaction
-	var err error
+// How long a transaction can be missing from the client before it's considered dropped, by default
+const DefaultDropTimeout = 60 * time.Second
 
-	// Get the transaction from its hash, retrying for 30 sec if it wasn't found
-	for i := 0; i < 30; i++ {
-		if i == 29 {
-			return nil, fmt.Errorf("Transaction not found after 30 seconds.")
+var (
+	ErrTransactionFailed   = errors.New("Transaction failed with status 0")
+	ErrTransactionReplaced = errors.New("Transaction was replaced by another transaction with the same nonce")
+	ErrTransactionDropped  = errors.New("Transaction was dropped by the Execution client")
+)
+
+// Wait for a transaction to be mined and buried under the given number of blocks (counting its own).
+// Reorgs that move or un-mine the transaction are followed until it's final.
+// Returns ErrTransactionReplaced if it will never be mined, ErrTransactionDropped if the client loses it for longer than dropTimeout
+// (never if it's 0), and the context's error if it's cancelled first.
+func WaitForTransaction(ctx context.Context, client stader.ExecutionClient, hash common.Hash, confirmations uint64, dropTimeout time.Duration) (*types.Receipt, error) {
+
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	var sender common.Address
+	var nonce uint64
+	isKnown := false
+	lastSeen := time.Now()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+
+		// Get the sender's confirmed nonce before the receipt, so a consumed nonce without a receipt means the transaction was replaced
+		var confirmedNonce uint64
+		if isKnown {
+			var err error
+			confirmedNonce, err = client.NonceAt(ctx, sender, nil)
+			if err != nil {
+				return nil, fmt.Errorf("Error getting nonce of %s: %w", sender.Hex(), err)
+			}
 		}
 
-		tx, _, err = client.TransactionByHash(context.Background(), hash)
-		if err != nil {
-			if err.Error() == "not found" {
-				time.Sleep(1 * time.Second)
-				continue
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			lastSeen = time.Now()
+			isFinal, err := isReceiptFinal(ctx, client, receipt, confirmations)
+			if err != nil {
+				return nil, err
+			}
+			if isFinal {
+				if receipt.Status == types.ReceiptStatusFailed {
+					return receipt, ErrTransactionFailed
+				}
+				return receipt, nil
+			}
+		} else if errors.Is(err, ethereum.NotFound) {
+			if isKnown && confirmedNonce > nonce {
+				return nil, fmt.Errorf("%w (nonce %d)", ErrTransactionReplaced, nonce)
+			}
+
+			// Check that the client still has it
+			tx, _, err := client.TransactionByHash(ctx, hash)
+			if err == nil {
+				lastSeen = time.Now()
+				if !isKnown {
+					sender, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+					if err != nil {
+						return nil, fmt.Errorf("Error getting sender of transaction %s: %w", hash.Hex(), err)
+					}
+					nonce = tx.Nonce()
+					isKnown = true
+				}
+			} else if !errors.Is(err, ethereum.NotFound) {
+				return nil, fmt.Errorf("Error getting transaction %s: %w", hash.Hex(), err)
+			} else if dropTimeout > 0 && time.Since(lastSeen) > dropTimeout {
+				return nil, fmt.Errorf("%w (missing for %s)", ErrTransactionDropped, dropTimeout)
 			}
-			return nil, err
 		} else {
-			break
+			return nil, fmt.Errorf("Error getting receipt for transaction %s: %w", hash.Hex(), err)
 		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("Stopped waiting for transaction %s: %w", hash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+
 	}
+}
 
-	// Wait for transaction to be mined
-	txReceipt, err := bind.WaitMined(context.Background(), client, tx)
+// Check if a receipt has enough confirmations and its block is still part of the canonical chain
+func isReceiptFinal(ctx context.Context, client stader.ExecutionClient, receipt *types.Receipt, confirmations uint64) (bool, error) {
+	head, err := client.BlockNumber(ctx)
 	if err != nil {
-		return nil, err
+		return false, fmt.Errorf("Error getting latest block number: %w", err)
 	}
-
-	// Check transaction status
-	if txReceipt.Status == 0 {
-		return txReceipt, errors.New("Transaction failed with status 0")
+	minedBlock := receipt.BlockNumber.Uint64()
+	if head < minedBlock || head-minedBlock+1 < confirmations {
+		return false, nil
 	}
 
-	// Return
-	return txReceipt, nil
+	// A reorg may have replaced the block since the receipt was read
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("Error getting block %d: %w", minedBlock, err)
+	}
+	return header.Hash() == receipt.BlockHash, nil
 }
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// What the fake client reports during one poll
+type chainState struct {
+	receipt *types.Receipt           // nil if the transaction isn't mined
+	pending bool                     // whether the client still has the transaction
+	nonce   uint64                   // the sender's confirmed nonce
+	head    uint64                   // the latest block number
+	blocks  map[uint64]*types.Header // the canonical chain
+}
+
+// An Execution client that steps through a script of chain states, one per poll; the last state repeats
+type fakeClient struct {
+	stader.ExecutionClient
+	tx      *types.Transaction
+	script  []chainState
+	polls   int
+	current chainState
+}
+
+// The state of the next poll
+func (f *fakeClient) next() chainState {
+	if f.polls < len(f.script) {
+		return f.script[f.polls]
+	}
+	return f.script[len(f.script)-1]
+}
+
+func (f *fakeClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return f.next().nonce, nil
+}
+
+func (f *fakeClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	f.current = f.next()
+	f.polls++
+	if f.current.receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return f.current.receipt, nil
+}
+
+func (f *fakeClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if !f.current.pending {
+		return nil, false, ethereum.NotFound
+	}
+	return f.tx, true, nil
+}
+
+func (f *fakeClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.current.head, nil
+}
+
+func (f *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	header, exists := f.current.blocks[number.Uint64()]
+	if !exists {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+// A block on one of several forks
+func newBlock(number uint64, fork byte) *types.Header {
+	return &types.Header{
+		Number: new(big.Int).SetUint64(number),
+		Extra:  []byte{fork},
+	}
+}
+
+// A receipt for the transaction in a block
+func newReceipt(block *types.Header, status uint64) *types.Receipt {
+	return &types.Receipt{
+		Status:      status,
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash(),
+	}
+}
+
+func TestWaitForTransaction(t *testing.T) {
+
+	pollInterval := waitPollInterval
+	waitPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		waitPollInterval = pollInterval
+	})
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x01")
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1337),
+		Nonce:     4,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+	}), types.LatestSignerForChainID(big.NewInt(1337)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block10a := newBlock(10, 'a')
+	block10b := newBlock(10, 'b')
+	block11b := newBlock(11, 'b')
+
+	tests := []struct {
+		name          string
+		script        []chainState
+		confirmations uint64
+		dropTimeout   time.Duration
+		timeout       time.Duration
+		wantBlock     common.Hash
+		wantErr       error
+	}{
+		{
+			name: "mined",
+			script: []chainState{
+				{pending: true, nonce: 4, head: 9},
+				{receipt: newReceipt(block10a, types.ReceiptStatusSuccessful), nonce: 5, head: 10, blocks: map[uint64]*types.Header{10: block10a}},
+			},
+			confirmations: 1,
+			wantBlock:     block10a.Hash(),
+		},
+		{
+			name: "mined then reorged out",
+			script: []chainState{
+				{receipt: newReceipt(block10a, types.ReceiptStatusSuccessful), nonce: 5, head: 10, blocks: map[uint64]*types.Header{10: block10a}},
+				{pending: true, nonce: 4, head: 10, blocks: map[uint64]*types.Header{10: block10b}},
+				{receipt: newReceipt(block11b, types.ReceiptStatusSuccessful), nonce: 5, head: 12, blocks: map[uint64]*types.Header{10: block10b, 11: block11b}},
+			},
+			confirmations: 2,
+			wantBlock:     block11b.Hash(),
+		},
+		{
+			name: "receipt in a non-canonical block",
+			script: []chainState{
+				{receipt: newReceipt(block10a, types.ReceiptStatusSuccessful), nonce: 5, head: 12, blocks: map[uint64]*types.Header{10: block10b}},
+				{receipt: newReceipt(block10b, types.ReceiptStatusSuccessful), nonce: 5, head: 12, blocks: map[uint64]*types.Header{10: block10b}},
+			},
+			confirmations: 1,
+			wantBlock:     block10b.Hash(),
+		},
+		{
+			name: "nonce consumed by another transaction",
+			script: []chainState{
+				{pending: true, nonce: 4, head: 9},
+				{nonce: 5, head: 10},
+			},
+			confirmations: 1,
+			wantErr:       ErrTransactionReplaced,
+		},
+		{
+			name: "missing past the drop timeout",
+			script: []chainState{
+				{nonce: 4, head: 9},
+			},
+			confirmations: 1,
+			dropTimeout:   20 * time.Millisecond,
+			wantErr:       ErrTransactionDropped,
+		},
+		{
+			name: "status 0",
+			script: []chainState{
+				{receipt: newReceipt(block10a, types.ReceiptStatusFailed), nonce: 5, head: 10, blocks: map[uint64]*types.Header{10: block10a}},
+			},
+			confirmations: 1,
+			wantBlock:     block10a.Hash(),
+			wantErr:       ErrTransactionFailed,
+		},
+		{
+			name: "context deadline",
+			script: []chainState{
+				{pending: true, nonce: 4, head: 9},
+			},
+			confirmations: 1,
+			dropTimeout:   time.Hour,
+			timeout:       20 * time.Millisecond,
+			wantErr:       context.DeadlineExceeded,
+		},
+		{
+			name: "missing without a drop timeout",
+			script: []chainState{
+				{nonce: 4, head: 9},
+			},
+			confirmations: 1,
+			timeout:       20 * time.Millisecond,
+			wantErr:       context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			timeout := test.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			client := &fakeClient{tx: tx, script: test.script}
+			receipt, err := WaitForTransaction(ctx, client, tx.Hash(), test.confirmations, test.dropTimeout)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, expected %v", err, test.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if test.wantBlock != (common.Hash{}) {
+				if receipt == nil {
+					t.Fatal("got no receipt")
+				}
+				if receipt.BlockHash != test.wantBlock {
+					t.Fatalf("got receipt in block %s, expected %s", receipt.BlockHash.Hex(), test.wantBlock.Hex())
+				}
+			}
+		})
+	}
+
+}
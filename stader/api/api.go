@@ -24,11 +24,10 @@ import (
 	"github.com/stader-labs/stader-node/stader/api/validator"
 	"github.com/urfave/cli"
 
-	"github.com/stader-labs/stader-node/shared/services"
 	apitypes "github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/api"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
-	"github.com/stader-labs/stader-node/stader-lib/utils"
+	"github.com/stader-labs/stader-node/shared/utils/eth1"
 	"github.com/stader-labs/stader-node/stader/api/node"
 	apiservice "github.com/stader-labs/stader-node/stader/api/service"
 	"github.com/stader-labs/stader-node/stader/api/wallet"
@@ -37,15 +36,11 @@ import (
 // Waits for an auction transaction
 func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse, error) {
 
-	prn, err := services.GetPermissionlessNodeRegistry(c)
-	if err != nil {
-		return nil, err
-	}
-
 	// Response
 	response := apitypes.APIResponse{}
-	_, err = utils.WaitForTransaction(prn.Client, hash)
-	if err != nil {
+
+	// Wait for it and log the actual cost in the transaction journal
+	if _, err := eth1.WaitForTransaction(c, hash); err != nil {
 		return nil, err
 	}
 
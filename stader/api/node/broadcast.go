@@ -0,0 +1,48 @@
+package node
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func broadcastTransaction(c *cli.Context, tx *types.Transaction) (*api.NodeBroadcastResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeAccount(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeBroadcastResponse{}
+
+	// Check it against the transaction policy and record it like a transaction signed on this machine
+	if err := w.RecordSignedTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	// Send it
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, err
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
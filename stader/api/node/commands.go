@@ -72,6 +72,164 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-gas-prices",
+				Usage:     "Get gas price suggestions from the Execution client's recent fee history",
+				UsageText: "stader-cli api node get-gas-prices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasPrices(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "tx-list",
+				Usage:     "Get the pending and recent transactions sent by the node account",
+				UsageText: "stader-cli api node tx-list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactions(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "tx-history",
+				Usage:     "Get the journal of transactions sent by the node account",
+				UsageText: "stader-cli api node tx-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactionHistory(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "broadcast",
+				Usage:     "Send a node account transaction that was signed offline",
+				UsageText: "stader-cli api node broadcast signed-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					tx, err := cliutils.ValidateTransaction("signed tx", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastTransaction(c, tx))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-tx-speed-up",
+				Usage:     "Check whether a node account transaction can be sped up",
+				UsageText: "stader-cli api node can-tx-speed-up tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, hash, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "tx-speed-up",
+				Usage:     "Resend a pending node account transaction with higher fees",
+				UsageText: "stader-cli api node tx-speed-up tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, hash, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-tx-cancel",
+				Usage:     "Check whether a node account transaction can be cancelled",
+				UsageText: "stader-cli api node can-tx-cancel tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, hash, true))
+					return nil
+
+				},
+			},
+			{
+				Name:      "tx-cancel",
+				Usage:     "Cancel a pending node account transaction by replacing it with an empty transfer to the node account",
+				UsageText: "stader-cli api node tx-cancel tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, hash, true))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-register",
 				Usage:     "Check whether the node can be registered with Stader",
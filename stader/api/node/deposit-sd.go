@@ -7,7 +7,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/stader-lib/tokens"
-	"github.com/stader-labs/stader-node/stader-lib/utils"
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services"
@@ -187,13 +186,9 @@ func waitForApprovalAndDepositSd(c *cli.Context, amountWei *big.Int, hash common
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
-	prn, err := services.GetPermissionlessNodeRegistry(c)
-	if err != nil {
-		return nil, err
-	}
 
-	_, err = utils.WaitForTransaction(prn.Client, hash)
-	if err != nil {
+	// Wait for the approval
+	if _, err := eth1.WaitForTransaction(c, hash); err != nil {
 		return nil, err
 	}
 
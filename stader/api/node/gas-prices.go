@@ -0,0 +1,35 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/gas/feehistory"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func getGasPrices(c *cli.Context) (*api.GasPricesResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasPricesResponse{}
+
+	// Get the suggestions
+	suggestion, err := feehistory.GetGasPrices(ec)
+	if err != nil {
+		return nil, err
+	}
+	response.Suggestion = suggestion
+
+	// Return response
+	return &response, nil
+
+}
@@ -38,7 +38,7 @@ import (
 func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNodeSendResponse, error) {
 
 	// Get services
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAccount(c); err != nil {
 		return nil, err
 	}
 	w, err := services.GetWallet(c)
@@ -128,7 +128,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Address) (*api.NodeSendResponse, error) {
 
 	// Get services
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAccount(c); err != nil {
 		return nil, err
 	}
 	w, err := services.GetWallet(c)
@@ -195,6 +195,14 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
 	}
 
+	// Return the transaction unsigned if it's being exported for offline signing
+	if w.IsOfflineExport() {
+		response.OfflineTx, err = w.GetExportedTransaction(c.Command.FullName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Return response
 	return &response, nil
 
@@ -0,0 +1,402 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/eth1"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// Transaction statuses
+const (
+	txStatusPending  string = "pending"
+	txStatusMined    string = "mined"
+	txStatusFailed   string = "failed"
+	txStatusReplaced string = "replaced"
+	txStatusDropped  string = "dropped"
+)
+
+// The gas limit of a plain ETH transfer, used for cancellations
+const cancelGasLimit uint64 = 21000
+
+func getTransactions(c *cli.Context) (*api.NodeTxListResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxListResponse{
+		Transactions: []api.NodeTransaction{},
+	}
+
+	// Get the node account's nonces
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.ConfirmedNonce, err = ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting confirmed nonce: %w", err)
+	}
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting pending nonce: %w", err)
+	}
+
+	// Get the status of each recorded transaction, newest first
+	signedTransactions, err := w.GetSignedTransactions()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(signedTransactions) - 1; i >= 0; i-- {
+		signedTransaction := signedTransactions[i]
+		tx, err := signedTransaction.Decode()
+		if err != nil {
+			return nil, err
+		}
+		transaction, err := getNodeTransaction(ec, tx, signedTransaction, response.ConfirmedNonce)
+		if err != nil {
+			return nil, err
+		}
+		response.Transactions = append(response.Transactions, transaction)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getTransactionHistory(c *cli.Context) (*api.NodeTxHistoryResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxHistoryResponse{}
+
+	// Get the journal
+	response.Transactions, err = w.GetTxJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in the costs of transactions nobody waited for, such as ones sent with --no-wait or broadcast from elsewhere
+	backfilled, err := backfillTxReceipts(c, w, response.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	if backfilled {
+		response.Transactions, err = w.GetTxJournal()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Record the receipts of journal transactions that have since been mined, and mark the ones that never will be because their nonce was used by
+// another transaction (they were replaced, or their broadcast failed after they were signed); returns true if any were recorded.
+// This is best-effort: if the Execution client can't be reached, the journal is shown as it is.
+func backfillTxReceipts(c *cli.Context, w *wallet.Wallet, transactions []wallet.TxJournalEntry) (bool, error) {
+	pending := false
+	for _, transaction := range transactions {
+		if !transaction.Mined && !transaction.Dropped {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		return false, nil
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return false, nil
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return false, nil
+	}
+	confirmedNonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return false, nil
+	}
+
+	backfilled := false
+	for _, transaction := range transactions {
+		if transaction.Mined || transaction.Dropped {
+			continue
+		}
+		receipt, err := ec.TransactionReceipt(context.Background(), transaction.Hash)
+		if errors.Is(err, ethereum.NotFound) {
+			if transaction.Nonce < confirmedNonce {
+				if err := w.RecordTxDropped(transaction.Hash); err != nil {
+					return backfilled, err
+				}
+				backfilled = true
+			}
+			continue
+		} else if err != nil {
+			return backfilled, nil
+		}
+		if err := eth1.RecordTxReceipt(c, receipt); err != nil {
+			return backfilled, err
+		}
+		backfilled = true
+	}
+	return backfilled, nil
+}
+
+func canReplaceTransaction(c *cli.Context, hash common.Hash, cancel bool) (*api.CanNodeReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeReplaceTxResponse{}
+
+	// Find the transaction
+	signedTransaction, err := getSignedTransaction(w, hash)
+	if err != nil {
+		return nil, err
+	}
+	if signedTransaction == nil {
+		response.NotFound = true
+		return &response, nil
+	}
+	tx, err := signedTransaction.Decode()
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	confirmedNonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting confirmed nonce: %w", err)
+	}
+	response.Transaction, err = getNodeTransaction(ec, tx, *signedTransaction, confirmedNonce)
+	if err != nil {
+		return nil, err
+	}
+	switch response.Transaction.Status {
+	case txStatusMined, txStatusFailed, txStatusReplaced:
+		response.AlreadyMined = true
+	}
+
+	// Get the fees the replacement must pay at least
+	response.MinMaxFeeWei = getMinReplacementFee(tx.GasFeeCap())
+	response.MinPriorityFeeWei = getMinReplacementFee(tx.GasTipCap())
+	gasLimit := tx.Gas()
+	if cancel {
+		gasLimit = cancelGasLimit
+	}
+	response.GasInfo = stader.GasInfo{
+		EstGasLimit:  gasLimit,
+		SafeGasLimit: gasLimit,
+	}
+
+	// Update & return response
+	response.CanReplace = !(response.NotFound || response.AlreadyMined)
+	return &response, nil
+
+}
+
+func replaceTransaction(c *cli.Context, hash common.Hash, cancel bool) (*api.NodeReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeReplaceTxResponse{}
+
+	// Find the transaction
+	signedTransaction, err := getSignedTransaction(w, hash)
+	if err != nil {
+		return nil, err
+	}
+	if signedTransaction == nil {
+		return nil, fmt.Errorf("transaction %s was not sent by this node", hash.Hex())
+	}
+	tx, err := signedTransaction.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the requested fees, raised to the minimum the network accepts for a replacement
+	maxFee := getMaxBigInt(opts.GasFeeCap, getMinReplacementFee(tx.GasFeeCap()))
+	priorityFee := getMaxBigInt(opts.GasTipCap, getMinReplacementFee(tx.GasTipCap()))
+	if priorityFee.Cmp(maxFee) > 0 {
+		maxFee = priorityFee
+	}
+
+	// Build the replacement; a cancellation is an empty transfer to the node account itself
+	replacement := &types.DynamicFeeTx{
+		ChainID:    w.GetChainID(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  priorityFee,
+		GasFeeCap:  maxFee,
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	if cancel {
+		replacement.Gas = cancelGasLimit
+		replacement.To = &opts.From
+		replacement.Value = big.NewInt(0)
+		replacement.Data = []byte{}
+		replacement.AccessList = types.AccessList{}
+	}
+
+	// Sign and send it
+	signedTx, err := opts.Signer(opts.From, types.NewTx(replacement))
+	if err != nil {
+		return nil, err
+	}
+	if err := ec.SendTransaction(context.Background(), signedTx); err != nil {
+		return nil, err
+	}
+	response.TxHash = signedTx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a transaction from the node's record, or nil if it isn't there
+func getSignedTransaction(w *wallet.Wallet, hash common.Hash) (*wallet.SignedTransaction, error) {
+	signedTransactions, err := w.GetSignedTransactions()
+	if err != nil {
+		return nil, err
+	}
+	for _, signedTransaction := range signedTransactions {
+		if signedTransaction.Hash == hash {
+			return &signedTransaction, nil
+		}
+	}
+	return nil, nil
+}
+
+// Get the details and status of a recorded transaction
+func getNodeTransaction(ec stader.ExecutionClient, tx *types.Transaction, signedTransaction wallet.SignedTransaction, confirmedNonce uint64) (api.NodeTransaction, error) {
+	transaction := api.NodeTransaction{
+		Hash:              tx.Hash(),
+		Nonce:             tx.Nonce(),
+		To:                tx.To(),
+		ValueWei:          tx.Value(),
+		MaxFeeWei:         tx.GasFeeCap(),
+		MaxPriorityFeeWei: tx.GasTipCap(),
+		GasLimit:          tx.Gas(),
+		SignedAt:          signedTransaction.SignedAt,
+	}
+
+	// Check if it was mined
+	receipt, err := ec.TransactionReceipt(context.Background(), tx.Hash())
+	if err == nil {
+		transaction.BlockNumber = receipt.BlockNumber.Uint64()
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			transaction.Status = txStatusMined
+		} else {
+			transaction.Status = txStatusFailed
+		}
+		return transaction, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return api.NodeTransaction{}, fmt.Errorf("Error getting receipt for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+
+	// Another transaction with the same nonce was mined
+	if tx.Nonce() < confirmedNonce {
+		transaction.Status = txStatusReplaced
+		return transaction, nil
+	}
+
+	// Check if it's still in the mempool
+	_, isPending, err := ec.TransactionByHash(context.Background(), tx.Hash())
+	if err == nil && isPending {
+		transaction.Status = txStatusPending
+	} else if err == nil || errors.Is(err, ethereum.NotFound) {
+		transaction.Status = txStatusDropped
+	} else {
+		return api.NodeTransaction{}, fmt.Errorf("Error getting transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	return transaction, nil
+}
+
+// Get the lowest fee that clients accept for a replacement transaction: 10% more than the original, rounded up
+func getMinReplacementFee(fee *big.Int) *big.Int {
+	minFee := new(big.Int).Mul(fee, big.NewInt(110))
+	minFee.Add(minFee, big.NewInt(99))
+	return minFee.Div(minFee, big.NewInt(100))
+}
+
+func getMaxBigInt(a *big.Int, b *big.Int) *big.Int {
+	if a == nil || a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
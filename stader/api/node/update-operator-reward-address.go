@@ -12,7 +12,7 @@ import (
 )
 
 func CanUpdateOperatorRewardAddress(c *cli.Context, operatorRewardAddress common.Address) (*api.CanUpdateOperatorRewardAddress, error) {
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAccount(c); err != nil {
 		return nil, err
 	}
 	if err := services.RequireNodeRegistered(c); err != nil {
@@ -121,5 +121,13 @@ func SetRewardAddress(c *cli.Context, operatorRewardAddress common.Address) (*ap
 
 	response.TxHash = tx.Hash()
 
+	// Return the transaction unsigned if it's being exported for offline signing
+	if w.IsOfflineExport() {
+		response.OfflineTx, err = w.GetExportedTransaction(c.Command.FullName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &response, nil
 }
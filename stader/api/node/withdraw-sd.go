@@ -12,7 +12,7 @@ import (
 )
 
 func canWithdrawSd(c *cli.Context, amountWei *big.Int) (*api.CanWithdrawSdResponse, error) {
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAccount(c); err != nil {
 		return nil, err
 	}
 	if err := services.RequireNodeRegistered(c); err != nil {
@@ -124,6 +124,14 @@ func withdrawSd(c *cli.Context, amountWei *big.Int) (*api.WithdrawSdResponse, er
 
 	response.TxHash = tx.Hash()
 
+	// Return the transaction unsigned if it's being exported for offline signing
+	if w.IsOfflineExport() {
+		response.OfflineTx, err = w.GetExportedTransaction(c.Command.FullName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Return response
 	return &response, nil
 }
@@ -37,12 +37,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "can-deposit",
 				Usage:     "Check whether the node can make a deposit to create a validator",
-				UsageText: "stader-cli api validator can-deposit amount salt num-validators reload-keys",
+				UsageText: "stader-cli api validator can-deposit amount num-validators reload-keys use-imported-keys",
 				Action: func(c *cli.Context) error {
 
 					//// Validate args
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
 						return err
 					}
 					amountWei, err := cliutils.ValidateWeiAmount("deposit amount", c.Args().Get(0))
@@ -60,7 +60,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
-					api.PrintResponse(canNodeDeposit(c, amountWei, numValidators, reloadKeys))
+					useImportedKeys, err := cliutils.ValidateBool("use-imported-keys", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					api.PrintResponse(canNodeDeposit(c, amountWei, numValidators, reloadKeys, useImportedKeys))
 
 					return nil
 
@@ -70,11 +75,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Name:      "deposit",
 				Aliases:   []string{"d"},
 				Usage:     "Make a deposit and create a validator",
-				UsageText: "stader-cli api validator deposit amount num-validators reload-keys",
+				UsageText: "stader-cli api validator deposit amount num-validators reload-keys use-imported-keys",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
 						return err
 					}
 					amountWei, err := cliutils.ValidateWeiAmount("deposit amount", c.Args().Get(0))
@@ -92,8 +97,13 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
+					useImportedKeys, err := cliutils.ValidateBool("use-imported-keys", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
 					// Run
-					response, err := nodeDeposit(c, amountWei, numValidators, reloadKeys)
+					response, err := nodeDeposit(c, amountWei, numValidators, reloadKeys, useImportedKeys)
 					api.PrintResponse(response, err)
 
 					return nil
@@ -142,6 +152,149 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "duties",
+				Usage:     "Get the upcoming proposer and sync committee duties of the operator validators",
+				UsageText: "stader-cli api validator duties epochs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					epochs, err := cliutils.ValidatePositiveUint("epochs", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidatorDuties(c, epochs))
+					return nil
+
+				},
+			},
+			{
+				Name:      "check-for-doppelgangers",
+				Usage:     "Watch the operator validators for a number of epochs and report any that are live elsewhere",
+				UsageText: "stader-cli api validator check-for-doppelgangers epochs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					epochs, err := cliutils.ValidatePositiveUint("epochs", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkForDoppelgangers(c, epochs))
+					return nil
+
+				},
+			},
+			{
+				Name:      "settings",
+				Usage:     "Get the graffiti and gas limit of the operator validators and the configured ones",
+				UsageText: "stader-cli api validator settings",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidatorSettings(c, false, false, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "apply-settings",
+				Usage:     "Apply the configured graffiti and gas limit to the operator validators through the keymanager API, removing the overrides of settings that were just cleared",
+				UsageText: "stader-cli api validator apply-settings clear-graffiti clear-gas-limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					clearGraffiti, err := cliutils.ValidateBool("clear-graffiti", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					clearGasLimit, err := cliutils.ValidateBool("clear-gas-limit", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidatorSettings(c, true, clearGraffiti, clearGasLimit))
+					return nil
+
+				},
+			},
+			{
+				Name:      "reconcile",
+				Usage:     "Compare the operator validators on chain with the wallet, the keystores and the validator client",
+				UsageText: "stader-cli api validator reconcile verify-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					verifyKeys, err := cliutils.ValidateBool("verify-keys", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(reconcileValidatorKeys(c, verifyKeys))
+					return nil
+
+				},
+			},
+			{
+				Name:      "remove-key",
+				Usage:     "Remove the key of a validator with no more duties from the keystores and the validator client",
+				UsageText: "stader-cli api validator remove-key validator-pub-key",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					validatorPubKey, err := cliutils.ValidatePubkey("validator-pub-key", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(removeValidatorKey(c, validatorPubKey))
+					return nil
+
+				},
+			},
+			{
+				Name:      "import-keystores",
+				Usage:     "Import the EIP-2335 keystores in the custom keys folder as validator keys",
+				UsageText: "stader-cli api validator import-keystores",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importKeystores(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-send-cl-rewards",
 				Usage:     "Can send cl rewards of a validator to the operator claim vault",
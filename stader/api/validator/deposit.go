@@ -2,21 +2,25 @@ package validator
 
 import (
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/stader-lib/node"
 	sd_collateral "github.com/stader-labs/stader-node/stader-lib/sd-collateral"
 	"github.com/stader-labs/stader-node/stader-lib/tokens"
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	_ "golang.org/x/sync/errgroup"
 	"math/big"
 
 	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
 	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/eth1"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
 	"github.com/stader-labs/stader-node/shared/utils/validator"
 )
 
-func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (*api.CanNodeDepositResponse, error) {
+func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool, useImportedKeys bool) (*api.CanNodeDepositResponse, error) {
 	if err := services.RequireNodeWallet(c); err != nil {
 		return nil, err
 	}
@@ -131,6 +135,19 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 		return &canNodeDepositResponse, nil
 	}
 
+	var importedKeys []wallet.ValidatorKey
+	if useImportedKeys {
+		importedKeys, err = getUnusedImportedKeys(c, operatorId, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(importedKeys)) < numValidators.Uint64() {
+			canNodeDepositResponse.NotEnoughImportedKeys = true
+			canNodeDepositResponse.UnusedImportedKeys = uint64(len(importedKeys))
+			return &canNodeDepositResponse, nil
+		}
+	}
+
 	pubKeys := make([][]byte, numValidators.Int64())
 	preDepositSignatures := make([][]byte, numValidators.Int64())
 	depositSignatures := make([][]byte, numValidators.Int64())
@@ -148,12 +165,17 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 	}
 
 	for i := int64(0); i < numValidators.Int64(); i++ {
-		// Create and save a new validator key
-		validatorKey, err := w.GetValidatorKeyAt(walletIndex)
-		if err != nil {
-			return nil, err
+		// Get the next validator key
+		var validatorKey *eth2types.BLSPrivateKey
+		if useImportedKeys {
+			validatorKey = importedKeys[i].PrivateKey
+		} else {
+			validatorKey, err = w.GetValidatorKeyAt(walletIndex)
+			if err != nil {
+				return nil, err
+			}
+			walletIndex++
 		}
-		walletIndex++
 
 		rewardWithdrawVault, err := node.ComputeWithdrawVaultAddress(vfc, 1, operatorId, newValidatorKey, nil)
 		if err != nil {
@@ -204,7 +226,7 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 	return &canNodeDepositResponse, nil
 }
 
-func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (*api.NodeDepositResponse, error) {
+func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool, useImportedKeys bool) (*api.NodeDepositResponse, error) {
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -271,14 +293,35 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 		return nil, err
 	}
 
+	var importedKeys []wallet.ValidatorKey
+	if useImportedKeys {
+		importedKeys, err = getUnusedImportedKeys(c, operatorId, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(importedKeys)) < numValidators.Uint64() {
+			return nil, fmt.Errorf("only %d imported validator keys haven't been used for a validator yet, but %d were requested", len(importedKeys), numValidators.Uint64())
+		}
+	}
+
 	newValidatorKey := validatorKeyCount
+	validatorKeys := make([]*eth2types.BLSPrivateKey, 0, numValidators.Int64())
 
 	for i := int64(0); i < numValidators.Int64(); i++ {
-		// Create and save a new validator key
-		validatorKey, err := w.CreateValidatorKey()
-		if err != nil {
-			return nil, err
+		// Create and save a new validator key, or make sure the imported one is in every keystore
+		var validatorKey *eth2types.BLSPrivateKey
+		if useImportedKeys {
+			validatorKey = importedKeys[i].PrivateKey
+			if err := w.StoreValidatorKey(validatorKey, importedKeys[i].DerivationPath); err != nil {
+				return nil, err
+			}
+		} else {
+			validatorKey, err = w.CreateValidatorKey()
+			if err != nil {
+				return nil, err
+			}
 		}
+		validatorKeys = append(validatorKeys, validatorKey)
 
 		rewardWithdrawVault, err := node.ComputeWithdrawVaultAddress(srcf, 1, operatorId, newValidatorKey, nil)
 		if err != nil {
@@ -332,8 +375,8 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 			return nil, err
 		}
 
-		// Restart the validator container when a new key have been saved
-		err = validator.RestartValidator(cfg, bc, nil, d)
+		// Load the new keys into the validator client, restarting it if that can't be done live
+		err = validator.LoadValidatorKeys(cfg, bc, nil, d, validatorKeys)
 		if err != nil {
 			return nil, err
 		}
@@ -361,3 +404,48 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 	return &response, nil
 
 }
+
+// Get the imported validator keys that aren't registered with the operator or on the Beacon Chain yet
+func getUnusedImportedKeys(c *cli.Context, operatorId *big.Int, nodeAddress common.Address) ([]wallet.ValidatorKey, error) {
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	prn, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	pubkeys, err := w.GetImportedValidatorPubkeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(pubkeys) == 0 {
+		return []wallet.ValidatorKey{}, nil
+	}
+	registeredValidators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(prn, operatorId, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []wallet.ValidatorKey{}
+	for _, pubkey := range pubkeys {
+		if _, registered := registeredValidators[pubkey]; registered || statuses[pubkey].Exists {
+			continue
+		}
+		key, err := w.GetImportedValidatorKey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
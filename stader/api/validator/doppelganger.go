@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+	"github.com/urfave/cli"
+)
+
+func checkForDoppelgangers(c *cli.Context, epochs uint64) (*api.CheckForDoppelgangersResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckForDoppelgangersResponse{
+		LiveValidators: []types.ValidatorPubkey{},
+	}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if operatorId.Int64() == 0 {
+		// An unregistered node has no validators to protect
+		return &response, nil
+	}
+	_, validatorPubKeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	indexPubkeys, err := stdr.GetValidatorIndices(bc, validatorPubKeys)
+	if err != nil {
+		return nil, err
+	}
+	indices := []uint64{}
+	for index := range indexPubkeys {
+		indices = append(indices, index)
+	}
+	response.ValidatorsChecked = len(indices)
+
+	// Watch the validators
+	liveIndices, err := validator.CheckForDoppelgangers(bc, indices, epochs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range liveIndices {
+		response.LiveValidators = append(response.LiveValidators, indexPubkeys[index])
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/urfave/cli"
+)
+
+func getValidatorDuties(c *cli.Context, epochs uint64) (*api.ValidatorDutiesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorDutiesResponse{
+		ProposerDuties: []api.ValidatorProposerDuty{},
+		SyncDuties:     []api.ValidatorSyncDuty{},
+	}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, validatorPubKeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	syncStatus, err := bc.GetSyncStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	// Beacon nodes only know the proposers of the current and next epoch, and the sync committees of the current and next period,
+	// so nothing past the end of the next period is checked
+	currentPeriod := eth2.SyncCommitteePeriodAt(eth2Config, head.Epoch)
+	response.CurrentEpoch = head.Epoch
+	response.EndEpoch = minEpoch(head.Epoch+epochs-1, (currentPeriod+2)*eth2Config.EpochsPerSyncCommitteePeriod-1)
+	response.ProposerLookaheadEpoch = minEpoch(response.EndEpoch, head.Epoch+1)
+	response.SyncLookaheadEpoch = response.EndEpoch
+
+	// Get the indices of the operator validators known to the beacon chain
+	indexPubkeys, err := stdr.GetValidatorIndices(bc, validatorPubKeys)
+	if err != nil {
+		return nil, err
+	}
+	indices := []uint64{}
+	for index := range indexPubkeys {
+		indices = append(indices, index)
+	}
+	response.ActiveValidators = len(indices)
+
+	busyEpochs := map[uint64]bool{}
+	if len(indices) > 0 {
+		// Get proposer duties
+		for epoch := head.Epoch; epoch <= response.ProposerLookaheadEpoch; epoch++ {
+			proposerSlots, err := bc.GetValidatorProposerDuties(indices, epoch)
+			if err != nil {
+				return nil, err
+			}
+			for index, slots := range proposerSlots {
+				for _, slot := range slots {
+					if slot <= syncStatus.HeadSlot {
+						// Already proposed or missed
+						continue
+					}
+					response.ProposerDuties = append(response.ProposerDuties, api.ValidatorProposerDuty{
+						Pubkey: indexPubkeys[index],
+						Index:  index,
+						Epoch:  epoch,
+						Slot:   slot,
+						Time:   time.Unix(int64(eth2.SlotTime(eth2Config, slot)), 0),
+					})
+					busyEpochs[epoch] = true
+				}
+			}
+		}
+
+		// Get sync committee duties
+		for period := currentPeriod; period <= currentPeriod+1; period++ {
+			periodStartEpoch := period * eth2Config.EpochsPerSyncCommitteePeriod
+			periodEndEpoch := periodStartEpoch + eth2Config.EpochsPerSyncCommitteePeriod - 1
+			if periodStartEpoch > response.EndEpoch {
+				break
+			}
+
+			syncDuties, err := bc.GetValidatorSyncDuties(indices, maxEpoch(periodStartEpoch, head.Epoch))
+			if err != nil {
+				return nil, err
+			}
+			for index, inCommittee := range syncDuties {
+				if !inCommittee {
+					continue
+				}
+				response.SyncDuties = append(response.SyncDuties, api.ValidatorSyncDuty{
+					Pubkey:     indexPubkeys[index],
+					Index:      index,
+					Period:     period,
+					StartEpoch: periodStartEpoch,
+					EndEpoch:   periodEndEpoch,
+					StartTime:  time.Unix(int64(eth2.EpochStartTime(eth2Config, periodStartEpoch)), 0),
+					EndTime:    time.Unix(int64(eth2.EpochStartTime(eth2Config, periodEndEpoch+1)), 0),
+				})
+				for epoch := maxEpoch(periodStartEpoch, head.Epoch); epoch <= minEpoch(periodEndEpoch, response.EndEpoch); epoch++ {
+					busyEpochs[epoch] = true
+				}
+			}
+		}
+	}
+
+	sort.Slice(response.ProposerDuties, func(i, j int) bool {
+		return response.ProposerDuties[i].Slot < response.ProposerDuties[j].Slot
+	})
+	sort.Slice(response.SyncDuties, func(i, j int) bool {
+		if response.SyncDuties[i].Period != response.SyncDuties[j].Period {
+			return response.SyncDuties[i].Period < response.SyncDuties[j].Period
+		}
+		return response.SyncDuties[i].Index < response.SyncDuties[j].Index
+	})
+
+	// Suggest the longest duty-free window within the range where all duties are known; proposers are the shortest lookahead.
+	// It can't start before the next slot, since the rest of the current epoch is already under way.
+	startTime := time.Unix(int64(eth2.SlotTime(eth2Config, syncStatus.HeadSlot+1)), 0)
+	response.DutyFreeWindow = getDutyFreeWindow(eth2Config, head.Epoch, startTime, response.ProposerLookaheadEpoch, busyEpochs)
+
+	return &response, nil
+}
+
+// Get the longest stretch of epochs in [startEpoch, endEpoch] without any duties.
+// A window in startEpoch begins at startTime instead of the start of the epoch, which has already passed.
+func getDutyFreeWindow(eth2Config beacon.Eth2Config, startEpoch uint64, startTime time.Time, endEpoch uint64, busyEpochs map[uint64]bool) *api.ValidatorDutyFreeWindow {
+	var window *api.ValidatorDutyFreeWindow
+	var bestLength time.Duration
+
+	runStart := startEpoch
+	for epoch := startEpoch; epoch <= endEpoch+1; epoch++ {
+		if epoch <= endEpoch && !busyEpochs[epoch] {
+			continue
+		}
+		if epoch > runStart {
+			windowStart := time.Unix(int64(eth2.EpochStartTime(eth2Config, runStart)), 0)
+			if runStart == startEpoch && startTime.After(windowStart) {
+				windowStart = startTime
+			}
+			windowEnd := time.Unix(int64(eth2.EpochStartTime(eth2Config, epoch)), 0)
+			if windowEnd.Sub(windowStart) > bestLength {
+				bestLength = windowEnd.Sub(windowStart)
+				window = &api.ValidatorDutyFreeWindow{
+					StartEpoch: runStart,
+					EndEpoch:   epoch - 1,
+					StartTime:  windowStart,
+					EndTime:    windowEnd,
+				}
+			}
+		}
+		runStart = epoch + 1
+	}
+
+	return window
+}
+
+func minEpoch(a uint64, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxEpoch(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
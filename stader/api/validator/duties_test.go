@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+)
+
+func TestGetDutyFreeWindow(t *testing.T) {
+	eth2Config := beacon.Eth2Config{
+		GenesisTime:     1000,
+		SecondsPerSlot:  12,
+		SlotsPerEpoch:   32,
+		SecondsPerEpoch: 384,
+	}
+	epochStart := func(epoch uint64) time.Time {
+		return time.Unix(int64(1000+epoch*384), 0)
+	}
+
+	// Most of epoch 10 has passed, so the rest of it is shorter than epoch 11
+	lateStart := epochStart(10).Add(300 * time.Second)
+	window := getDutyFreeWindow(eth2Config, 10, lateStart, 12, map[uint64]bool{11: true})
+	if window == nil || window.StartEpoch != 12 || window.EndEpoch != 12 {
+		t.Fatalf("expected epoch 12 to be the longest window, got %+v", window)
+	}
+
+	// A window in the current epoch starts now rather than when the epoch started
+	earlyStart := epochStart(10).Add(12 * time.Second)
+	window = getDutyFreeWindow(eth2Config, 10, earlyStart, 12, map[uint64]bool{12: true})
+	if window == nil || window.StartEpoch != 10 || window.EndEpoch != 11 {
+		t.Fatalf("expected epochs 10 - 11, got %+v", window)
+	}
+	if !window.StartTime.Equal(earlyStart) || !window.EndTime.Equal(epochStart(12)) {
+		t.Fatalf("expected the window to run from %s to %s, got %s to %s", earlyStart, epochStart(12), window.StartTime, window.EndTime)
+	}
+
+	// Every epoch is busy
+	if window := getDutyFreeWindow(eth2Config, 10, earlyStart, 11, map[uint64]bool{10: true, 11: true}); window != nil {
+		t.Fatalf("expected no window, got %+v", window)
+	}
+}
@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Import the EIP-2335 keystores in the custom keys folder, decrypting them with the passwords in the custom key passwords file
+func importKeystores(c *cli.Context) (*api.ImportKeystoresResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportKeystoresResponse{
+		Keystores: []api.ImportedKeystore{},
+	}
+
+	// Read the keystore passwords
+	passwordBytes, err := ioutil.ReadFile(os.ExpandEnv(cfg.StaderNode.GetCustomKeyPasswordFilePath()))
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom keystore passwords file: %w", err)
+	}
+	passwords := map[string]string{}
+	if err := yaml.Unmarshal(passwordBytes, &passwords); err != nil {
+		return nil, fmt.Errorf("error deserializing custom keystore passwords file: %w", err)
+	}
+
+	// Decrypt every keystore before importing any of them, so a wrong password doesn't leave a partial import
+	customKeyDir := os.ExpandEnv(cfg.StaderNode.GetCustomKeyPath())
+	files, err := ioutil.ReadDir(customKeyDir)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	keys := []wallet.ValidatorKey{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		keystoreBytes, err := ioutil.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+		}
+		pubkey, err := getKeystorePubkey(keystoreBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+		}
+		password, exists := passwords[strings.ToUpper(hexutils.RemovePrefix(pubkey.Hex()))]
+		if !exists {
+			return nil, fmt.Errorf("custom keystore %s has no password in the custom keystore passwords file", file.Name())
+		}
+		key, err := w.DecryptValidatorKeystore(keystoreBytes, password)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting custom keystore %s: %w", file.Name(), err)
+		}
+		keys = append(keys, key)
+		response.Keystores = append(response.Keystores, api.ImportedKeystore{
+			File:   file.Name(),
+			Pubkey: key.PublicKey,
+		})
+	}
+	if len(keys) == 0 {
+		return &response, nil
+	}
+
+	// Get the keys that are already in use
+	pubkeys := make([]types.ValidatorPubkey, 0, len(keys))
+	for _, key := range keys {
+		pubkeys = append(pubkeys, key.PublicKey)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	registeredValidators := map[types.ValidatorPubkey]bool{}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if operatorId.Sign() > 0 {
+		validators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		for pubkey := range validators {
+			registeredValidators[pubkey] = true
+		}
+	}
+	importedPubkeys, err := w.GetImportedValidatorPubkeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Import the keys that are free to register, and the ones already imported so they're back in every keystore
+	for i, key := range keys {
+		keystore := &response.Keystores[i]
+		alreadyImported := containsPubkey(importedPubkeys, key.PublicKey)
+		if !alreadyImported {
+			if registeredValidators[key.PublicKey] {
+				keystore.Result = api.ImportedKeystore_InUse
+				keystore.Detail = "This key is already registered with your operator."
+				continue
+			}
+			if status := statuses[key.PublicKey]; status.Exists {
+				keystore.Result = api.ImportedKeystore_InUse
+				keystore.Detail = fmt.Sprintf("This key is already a validator on the Beacon Chain (index %d, %s).", status.Index, status.Status)
+				continue
+			}
+		}
+
+		if err := w.ImportValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
+			return nil, err
+		}
+		if alreadyImported {
+			keystore.Result = api.ImportedKeystore_AlreadyImported
+		} else {
+			keystore.Result = api.ImportedKeystore_Imported
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the pubkey an EIP-2335 keystore is labelled with
+func getKeystorePubkey(keystoreBytes []byte) (types.ValidatorPubkey, error) {
+	var keystore struct {
+		Pubkey string `json:"pubkey"`
+	}
+	if err := json.Unmarshal(keystoreBytes, &keystore); err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+	if keystore.Pubkey == "" {
+		return types.ValidatorPubkey{}, fmt.Errorf("keystore has no pubkey")
+	}
+	return types.HexToValidatorPubkey(hexutils.RemovePrefix(keystore.Pubkey))
+}
@@ -0,0 +1,398 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+	"github.com/urfave/cli"
+)
+
+const (
+	// How many keys to derive at a time
+	reconcilePageSize uint = 20
+
+	// How far past the wallet's next index to look for registered keys
+	reconcileLookahead uint = 100
+)
+
+func reconcileValidatorKeys(c *cli.Context, verifyKeys bool) (*api.ValidatorReconcileResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorReconcileResponse{}
+
+	// Get the validators registered on chain
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	registeredValidators, registeredPubkeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.RegisteredValidators = len(registeredPubkeys)
+
+	// Derive the wallet's keys, and look past its next index for registered keys it doesn't know about
+	nextAccount, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	response.NextAccount = uint64(nextAccount)
+	derivedPubkeys := []types.ValidatorPubkey{}
+	derivedIndices := map[types.ValidatorPubkey]uint{}
+	unmatched := len(registeredValidators)
+	for start := uint(0); start < nextAccount || (unmatched > 0 && start < nextAccount+reconcileLookahead); start += reconcilePageSize {
+		keys, err := w.GetValidatorKeys(start, reconcilePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			derivedPubkeys = append(derivedPubkeys, key.PublicKey)
+			derivedIndices[key.PublicKey] = key.WalletIndex
+			if _, exists := registeredValidators[key.PublicKey]; exists {
+				unmatched--
+			}
+		}
+	}
+
+	// Get the keys that were imported instead of derived
+	importedPubkeys, err := w.GetImportedValidatorPubkeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the keys in the validator client's keystore
+	response.Keystore = validator.GetValidatorClientKeystore(cfg)
+	ks, exists := w.GetKeystore(response.Keystore)
+	if !exists {
+		return nil, fmt.Errorf("the node wallet has no %s keystore", response.Keystore)
+	}
+	localPubkeys, err := ks.ListValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+	local := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range localPubkeys {
+		local[pubkey] = true
+	}
+	response.LocalValidators = len(localPubkeys)
+
+	// Get the keys the validator client has loaded; without the keymanager API, assume it loaded its keystore
+	loadedPubkeys := localPubkeys
+	if keymanagerClient := validator.GetKeymanagerClient(cfg); keymanagerClient == nil {
+		response.KeymanagerUnavailable = true
+	} else if pubkeys, err := keymanagerClient.ListPubkeys(); errors.Is(err, keymanager.ErrUnavailable) {
+		response.KeymanagerUnavailable = true
+	} else if err != nil {
+		return nil, err
+	} else {
+		loadedPubkeys = pubkeys
+	}
+	loaded := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range loadedPubkeys {
+		loaded[pubkey] = true
+	}
+	response.LoadedValidators = len(loadedPubkeys)
+
+	// Get the beacon state of every key involved
+	unregisteredPubkeys := []types.ValidatorPubkey{}
+	for _, pubkeys := range [][]types.ValidatorPubkey{localPubkeys, loadedPubkeys} {
+		for _, pubkey := range pubkeys {
+			if _, registered := registeredValidators[pubkey]; registered || containsPubkey(unregisteredPubkeys, pubkey) {
+				continue
+			}
+			unregisteredPubkeys = append(unregisteredPubkeys, pubkey)
+		}
+	}
+	statuses, err := bc.GetValidatorStatuses(append(append([]types.ValidatorPubkey{}, registeredPubkeys...), unregisteredPubkeys...), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every key
+	keys := validatorKeys{
+		keystore:             response.Keystore,
+		registeredValidators: registeredValidators,
+		registeredPubkeys:    registeredPubkeys,
+		nextAccount:          nextAccount,
+		derivedPubkeys:       derivedPubkeys,
+		derivedIndices:       derivedIndices,
+		importedPubkeys:      importedPubkeys,
+		local:                local,
+		loaded:               loaded,
+		unregisteredPubkeys:  unregisteredPubkeys,
+		statuses:             statuses,
+	}
+	if verifyKeys {
+		keys.verifyKey = ks.VerifyValidatorKey
+	}
+	response.Findings = findValidatorKeyIssues(keys)
+
+	// Return response
+	return &response, nil
+
+}
+
+func removeValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) (*api.RemoveValidatorKeyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RemoveValidatorKeyResponse{}
+
+	// Refuse to remove the key of a validator that still has duties
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	registeredValidators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if validatorInfo, registered := registeredValidators[pubkey]; registered {
+		status, err := bc.GetValidatorStatus(pubkey, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !stdr.IsValidatorDone(validatorInfo, status) {
+			response.ValidatorActive = true
+			return &response, nil
+		}
+	}
+
+	// Delete the key from disk first so a validator client restart won't load it again
+	if err := w.DeleteValidatorKey(pubkey); err != nil {
+		return nil, err
+	}
+	if err := w.DeleteImportedValidatorKey(pubkey); err != nil {
+		return nil, err
+	}
+	if err := validator.UnloadValidatorKeys(cfg, bc, nil, d, []types.ValidatorPubkey{pubkey}); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// The keys involved in reconciling the validator keys, gathered from the chain, the wallet and the validator client
+type validatorKeys struct {
+	keystore             string
+	registeredValidators map[types.ValidatorPubkey]contracts.Validator
+	registeredPubkeys    []types.ValidatorPubkey
+	nextAccount          uint
+	derivedPubkeys       []types.ValidatorPubkey
+	derivedIndices       map[types.ValidatorPubkey]uint
+	importedPubkeys      []types.ValidatorPubkey
+	local                map[types.ValidatorPubkey]bool
+	loaded               map[types.ValidatorPubkey]bool
+	unregisteredPubkeys  []types.ValidatorPubkey
+	statuses             map[types.ValidatorPubkey]beacon.ValidatorStatus
+
+	// Checks that the validator client can load a key from the keystore, or nil to skip the check
+	verifyKey func(types.ValidatorPubkey) error
+}
+
+// Find the problems with the validator keys and how to fix them
+func findValidatorKeyIssues(keys validatorKeys) []api.ValidatorKeyFinding {
+	findings := []api.ValidatorKeyFinding{}
+	addFinding := func(kind string, pubkey types.ValidatorPubkey, detail string, suggestedFix string) {
+		findings = append(findings, api.ValidatorKeyFinding{
+			Kind:         kind,
+			Pubkey:       pubkey,
+			Detail:       detail,
+			SuggestedFix: suggestedFix,
+		})
+	}
+
+	// Check the registered validators
+	rebuildCommand := fmt.Sprintf("`stader-cli wallet rebuild-keystores --client %s`", keys.keystore)
+	for _, pubkey := range keys.registeredPubkeys {
+		validatorInfo := keys.registeredValidators[pubkey]
+		status := keys.statuses[pubkey]
+		index, derived := keys.derivedIndices[pubkey]
+
+		if derived && index >= keys.nextAccount {
+			addFinding(api.ValidatorKeyFinding_NextAccountBehind, pubkey,
+				fmt.Sprintf("This validator is registered with your operator and derived from wallet index %d, but the wallet's next index is %d.", index, keys.nextAccount),
+				fmt.Sprintf("Your next deposit would try to reuse this key and fail. Move the wallet past it with `stader-cli wallet set-next-index %d`.", index+1))
+		}
+
+		if stdr.IsValidatorDone(validatorInfo, status) {
+			if keys.loaded[pubkey] {
+				addFinding(api.ValidatorKeyFinding_TerminalLoaded, pubkey,
+					fmt.Sprintf("This validator is %s but its key is still loaded in the validator client.", describeDoneValidator(validatorInfo, status)),
+					fmt.Sprintf("It has no more duties, so remove its key with `stader-cli validator remove-key --validator-pub-key %s`.", pubkey.Hex()))
+			}
+			continue
+		}
+
+		if !keys.local[pubkey] {
+			// The validator client may have been given the key some other way
+			if keys.loaded[pubkey] {
+				continue
+			}
+			suggestedFix := fmt.Sprintf("Its key wasn't derived from this wallet's mnemonic in the first %d indices. Restore its keystore from your backup.", len(keys.derivedPubkeys))
+			if derived {
+				suggestedFix = fmt.Sprintf("Its key is derived from wallet index %d. Restore it with %s.", index, rebuildCommand)
+			} else if containsPubkey(keys.importedPubkeys, pubkey) {
+				suggestedFix = fmt.Sprintf("Its key was imported. Restore it with %s.", rebuildCommand)
+			}
+			addFinding(api.ValidatorKeyFinding_MissingLocally, pubkey,
+				fmt.Sprintf("This validator is registered with your operator but its key isn't in the %s keystore, so it isn't validating.", keys.keystore),
+				suggestedFix)
+			continue
+		}
+
+		if keys.verifyKey != nil {
+			if err := keys.verifyKey(pubkey); err != nil {
+				suggestedFix := "Restore its keystore from your backup."
+				if derived {
+					suggestedFix = fmt.Sprintf("Its key is derived from wallet index %d. Rewrite it with %s.", index, rebuildCommand)
+				} else if containsPubkey(keys.importedPubkeys, pubkey) {
+					suggestedFix = fmt.Sprintf("Its key was imported. Rewrite it with %s.", rebuildCommand)
+				}
+				addFinding(api.ValidatorKeyFinding_Unloadable, pubkey,
+					fmt.Sprintf("The validator client can't load this validator's key from the %s keystore: %s.", keys.keystore, err.Error()),
+					suggestedFix)
+			}
+		}
+	}
+
+	// Check the keys that aren't registered
+	for _, pubkey := range keys.unregisteredPubkeys {
+		status := keys.statuses[pubkey]
+		where := fmt.Sprintf("in the %s keystore", keys.keystore)
+		if keys.loaded[pubkey] {
+			where = "loaded in the validator client"
+		}
+		removeCommand := fmt.Sprintf("`stader-cli validator remove-key --validator-pub-key %s`", pubkey.Hex())
+
+		detail := fmt.Sprintf("This key is %s but isn't registered with your operator.", where)
+		var suggestedFix string
+		switch index, derived := keys.derivedIndices[pubkey]; {
+		case status.Exists && !stdr.HasValidatorExited(status):
+			detail += fmt.Sprintf(" The Beacon Chain shows it as %s, so it may be validating somewhere else.", status.Status)
+			suggestedFix = fmt.Sprintf("If another validator client runs this key, remove it here right away to avoid being slashed: %s.", removeCommand)
+		case containsPubkey(keys.importedPubkeys, pubkey):
+			detail += " It was imported and hasn't been registered yet."
+			suggestedFix = fmt.Sprintf("Register it with `stader-cli validator deposit --imported-keys`, or remove it with %s.", removeCommand)
+		case derived && index < keys.nextAccount:
+			detail += fmt.Sprintf(" It was derived from wallet index %d, most likely for a deposit that failed or was front-run.", index)
+			suggestedFix = fmt.Sprintf("Remove it with %s so it can never be run twice.", removeCommand)
+		default:
+			suggestedFix = fmt.Sprintf("Unless you're about to register it, remove it with %s.", removeCommand)
+		}
+		addFinding(api.ValidatorKeyFinding_Unregistered, pubkey, detail, suggestedFix)
+	}
+
+	// Check for wallet indices that were never registered
+	for start := uint(0); start < keys.nextAccount && start < uint(len(keys.derivedPubkeys)); start++ {
+		if _, registered := keys.registeredValidators[keys.derivedPubkeys[start]]; registered {
+			continue
+		}
+		end := start
+		for end+1 < keys.nextAccount {
+			if _, registered := keys.registeredValidators[keys.derivedPubkeys[end+1]]; registered {
+				break
+			}
+			end++
+		}
+		detail := fmt.Sprintf("Wallet index %d isn't registered with your operator.", start)
+		if end > start {
+			detail = fmt.Sprintf("Wallet indices %d to %d aren't registered with your operator.", start, end)
+		}
+		addFinding(api.ValidatorKeyFinding_IndexGap, types.ValidatorPubkey{}, detail,
+			"Nothing needs to be fixed: these indices were most likely used for deposits that failed or were front-run, and wallet recovery skips past them. Any of their keys left in the validator client are reported as unregistered.")
+		start = end
+	}
+
+	return findings
+}
+
+// Describe why a validator has no more duties
+func describeDoneValidator(validatorInfo contracts.Validator, status beacon.ValidatorStatus) string {
+	if stdr.IsValidatorTerminal(validatorInfo) {
+		return fmt.Sprintf("terminal (%s)", stdr.ValidatorState[validatorInfo.Status])
+	}
+	return string(status.Status)
+}
+
+func containsPubkey(pubkeys []types.ValidatorPubkey, pubkey types.ValidatorPubkey) bool {
+	for _, candidate := range pubkeys {
+		if candidate == pubkey {
+			return true
+		}
+	}
+	return false
+}
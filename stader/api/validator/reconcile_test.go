@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func testValidatorPubkey(b byte) types.ValidatorPubkey {
+	var pubkey types.ValidatorPubkey
+	pubkey[0] = b
+	return pubkey
+}
+
+// A wallet that derived keys 1 to 3 for indices 0 to 2, all registered, stored and loaded
+func newTestValidatorKeys() validatorKeys {
+	keys := validatorKeys{
+		keystore:             "lighthouse",
+		registeredValidators: map[types.ValidatorPubkey]contracts.Validator{},
+		nextAccount:          3,
+		derivedIndices:       map[types.ValidatorPubkey]uint{},
+		importedPubkeys:      []types.ValidatorPubkey{},
+		local:                map[types.ValidatorPubkey]bool{},
+		loaded:               map[types.ValidatorPubkey]bool{},
+		statuses:             map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+	}
+	for i := uint(0); i < 3; i++ {
+		pubkey := testValidatorPubkey(byte(i + 1))
+		keys.registeredValidators[pubkey] = contracts.Validator{Status: 4}
+		keys.registeredPubkeys = append(keys.registeredPubkeys, pubkey)
+		keys.derivedPubkeys = append(keys.derivedPubkeys, pubkey)
+		keys.derivedIndices[pubkey] = i
+		keys.local[pubkey] = true
+		keys.loaded[pubkey] = true
+		keys.statuses[pubkey] = beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_ActiveOngoing}
+	}
+	return keys
+}
+
+// Add a key that isn't registered with the operator
+func addUnregisteredKey(keys *validatorKeys, pubkey types.ValidatorPubkey) {
+	keys.unregisteredPubkeys = append(keys.unregisteredPubkeys, pubkey)
+	keys.local[pubkey] = true
+}
+
+func TestFindValidatorKeyIssues(t *testing.T) {
+	unregistered := testValidatorPubkey(9)
+
+	tests := []struct {
+		name                 string
+		setup                func(keys *validatorKeys)
+		expectedKinds        []string
+		expectedSuggestedFix string
+	}{
+		{
+			name:  "healthy",
+			setup: func(keys *validatorKeys) {},
+		},
+		{
+			name:                 "next account behind",
+			setup:                func(keys *validatorKeys) { keys.nextAccount = 2 },
+			expectedKinds:        []string{api.ValidatorKeyFinding_NextAccountBehind},
+			expectedSuggestedFix: "`stader-cli wallet set-next-index 3`",
+		},
+		{
+			name: "terminal validator loaded",
+			setup: func(keys *validatorKeys) {
+				keys.registeredValidators[testValidatorPubkey(2)] = contracts.Validator{Status: 2}
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_TerminalLoaded},
+			expectedSuggestedFix: "remove-key",
+		},
+		{
+			name: "exited validator unloaded",
+			setup: func(keys *validatorKeys) {
+				keys.statuses[testValidatorPubkey(2)] = beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_WithdrawalDone}
+				keys.loaded[testValidatorPubkey(2)] = false
+				keys.local[testValidatorPubkey(2)] = false
+			},
+		},
+		{
+			name: "derived key missing locally",
+			setup: func(keys *validatorKeys) {
+				keys.local[testValidatorPubkey(2)] = false
+				keys.loaded[testValidatorPubkey(2)] = false
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_MissingLocally},
+			expectedSuggestedFix: "wallet index 1. Restore it with `stader-cli wallet rebuild-keystores --client lighthouse`",
+		},
+		{
+			name: "unknown key missing locally",
+			setup: func(keys *validatorKeys) {
+				delete(keys.derivedIndices, testValidatorPubkey(2))
+				keys.local[testValidatorPubkey(2)] = false
+				keys.loaded[testValidatorPubkey(2)] = false
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_MissingLocally},
+			expectedSuggestedFix: "from your backup",
+		},
+		{
+			name:  "key loaded some other way",
+			setup: func(keys *validatorKeys) { keys.local[testValidatorPubkey(2)] = false },
+		},
+		{
+			name: "unloadable key",
+			setup: func(keys *validatorKeys) {
+				keys.verifyKey = func(pubkey types.ValidatorPubkey) error {
+					if pubkey == testValidatorPubkey(3) {
+						return errors.New("invalid keystore")
+					}
+					return nil
+				}
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_Unloadable},
+			expectedSuggestedFix: "Rewrite it with",
+		},
+		{
+			name: "unregistered key validating elsewhere",
+			setup: func(keys *validatorKeys) {
+				addUnregisteredKey(keys, unregistered)
+				keys.statuses[unregistered] = beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_ActiveOngoing}
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_Unregistered},
+			expectedSuggestedFix: "to avoid being slashed",
+		},
+		{
+			name: "unregistered imported key",
+			setup: func(keys *validatorKeys) {
+				addUnregisteredKey(keys, unregistered)
+				keys.importedPubkeys = append(keys.importedPubkeys, unregistered)
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_Unregistered},
+			expectedSuggestedFix: "deposit --imported-keys",
+		},
+		{
+			name: "unregistered key from a failed deposit",
+			setup: func(keys *validatorKeys) {
+				// Index 1 was derived but its deposit never registered
+				delete(keys.registeredValidators, testValidatorPubkey(2))
+				keys.registeredPubkeys = []types.ValidatorPubkey{testValidatorPubkey(1), testValidatorPubkey(3)}
+				delete(keys.statuses, testValidatorPubkey(2))
+				addUnregisteredKey(keys, testValidatorPubkey(2))
+			},
+			// It also leaves a gap in the registered wallet indices
+			expectedKinds:        []string{api.ValidatorKeyFinding_Unregistered, api.ValidatorKeyFinding_IndexGap},
+			expectedSuggestedFix: "so it can never be run twice",
+		},
+		{
+			name: "unregistered key from elsewhere",
+			setup: func(keys *validatorKeys) {
+				addUnregisteredKey(keys, unregistered)
+				keys.loaded[unregistered] = true
+			},
+			expectedKinds:        []string{api.ValidatorKeyFinding_Unregistered},
+			expectedSuggestedFix: "Unless you're about to register it",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := newTestValidatorKeys()
+			test.setup(&keys)
+			findings := findValidatorKeyIssues(keys)
+
+			kinds := []string{}
+			for _, finding := range findings {
+				kinds = append(kinds, finding.Kind)
+			}
+			if strings.Join(kinds, ",") != strings.Join(test.expectedKinds, ",") {
+				t.Fatalf("got findings %v, expected %v", kinds, test.expectedKinds)
+			}
+			if len(findings) == 0 {
+				return
+			}
+			if !strings.Contains(findings[0].SuggestedFix, test.expectedSuggestedFix) {
+				t.Fatalf("expected the suggested fix to contain %q, got %q", test.expectedSuggestedFix, findings[0].SuggestedFix)
+			}
+		})
+	}
+}
+
+func TestFindValidatorKeyIndexGaps(t *testing.T) {
+	keys := newTestValidatorKeys()
+
+	// Indices 0 and 1 were never registered, and neither was index 3 past them
+	for i := byte(4); i <= 5; i++ {
+		pubkey := testValidatorPubkey(i)
+		keys.derivedPubkeys = append(keys.derivedPubkeys, pubkey)
+		keys.derivedIndices[pubkey] = uint(i - 1)
+	}
+	keys.nextAccount = 5
+	keys.registeredPubkeys = []types.ValidatorPubkey{testValidatorPubkey(3), testValidatorPubkey(5)}
+	delete(keys.registeredValidators, testValidatorPubkey(1))
+	delete(keys.registeredValidators, testValidatorPubkey(2))
+	keys.registeredValidators[testValidatorPubkey(5)] = contracts.Validator{Status: 4}
+	keys.local[testValidatorPubkey(5)] = true
+	keys.loaded[testValidatorPubkey(5)] = true
+	keys.statuses[testValidatorPubkey(5)] = beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_ActiveOngoing}
+
+	findings := findValidatorKeyIssues(keys)
+	details := []string{}
+	for _, finding := range findings {
+		if finding.Kind != api.ValidatorKeyFinding_IndexGap || finding.Pubkey != (types.ValidatorPubkey{}) {
+			t.Fatalf("expected only index gaps, got %+v", finding)
+		}
+		details = append(details, finding.Detail)
+	}
+	expected := []string{
+		"Wallet indices 0 to 1 aren't registered with your operator.",
+		"Wallet index 3 isn't registered with your operator.",
+	}
+	if strings.Join(details, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("got index gaps %q, expected %q", details, expected)
+	}
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/urfave/cli"
+)
+
+// Get the validator settings, applying them if requested.
+// clearGraffiti and clearGasLimit remove the overrides of a setting that was just cleared; overrides Stader never set are left alone otherwise.
+func getValidatorSettings(c *cli.Context, apply bool, clearGraffiti bool, clearGasLimit bool) (*api.ValidatorSettingsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorSettingsResponse{
+		GraffitiTemplate: cfg.StaderNode.GraffitiTemplate.Value.(string),
+		GasLimit:         cfg.StaderNode.ValidatorGasLimit.Value.(uint64),
+		Validators:       []api.ValidatorSettings{},
+	}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	ecVersion, err := ec.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without the keymanager API, the graffiti reaches the validator client through a file it reads when it starts
+	if apply && !cfg.StaderNode.KeymanagerApi.Value.(bool) {
+		if _, err := validator.UpdateGraffitiFile(cfg, bc, pnr, operatorId, ecVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	// Applying also removes the overrides of the settings that were cleared
+	if apply {
+		clearGraffiti = clearGraffiti && response.GraffitiTemplate == ""
+		clearGasLimit = clearGasLimit && response.GasLimit == 0
+		err = validator.ClearValidatorSettings(cfg, pnr, operatorId, nodeAccount.Address, clearGraffiti, clearGasLimit)
+		if err != nil && !errors.Is(err, keymanager.ErrUnavailable) {
+			return nil, err
+		}
+	}
+
+	settings, err := validator.ReconcileValidatorSettings(cfg, bc, pnr, operatorId, nodeAccount.Address, ecVersion, apply)
+	if errors.Is(err, keymanager.ErrUnavailable) {
+		response.KeymanagerUnavailable = true
+		return &response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.Validators = settings
+
+	// Return response
+	return &response, nil
+
+}
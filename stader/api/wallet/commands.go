@@ -115,19 +115,45 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			},
 
 			{
-				Name:      "rebuild-validator-keys",
-				Aliases:   []string{"rvk"},
-				Usage:     "Rebuild a node wallet validator keys",
-				UsageText: "stader-cli api wallet rebuild-validator-keys",
-				Flags:     []cli.Flag{},
+				Name:      "rebuild-keystores",
+				Aliases:   []string{"rk"},
+				Usage:     "Rebuild a consensus client's keystore from the mnemonic and the imported validator keys",
+				UsageText: "stader-cli api wallet rebuild-keystores client force",
 				Action: func(c *cli.Context) error {
+
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					force, err := cliutils.ValidateBool("force", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(rebuildKeystores(c, c.Args().Get(0), force))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-next-index",
+				Usage:     "Move the wallet index the next validator key is derived from forward",
+				UsageText: "stader-cli api wallet set-next-index index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(rebuildValidatorKeys(c))
+					api.PrintResponse(setNextValidatorIndex(c, index))
 					return nil
 
 				},
@@ -177,6 +203,31 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a node account transaction that was exported for offline signing",
+				UsageText: "stader-cli api wallet sign-tx unsigned-tx from",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					tx, err := cliutils.ValidateTransaction("unsigned tx", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					from, err := cliutils.ValidateAddress("from", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signTransaction(c, tx, from))
+					return nil
+
+				},
+			},
 			{
 				Name:      "purge",
 				Usage:     "Deletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!",
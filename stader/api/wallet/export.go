@@ -60,12 +60,14 @@ func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
 	}
 	response.Wallet = wallet
 
-	// Get account private key
-	privateKey, err := w.GetNodePrivateKeyBytes()
-	if err != nil {
-		return nil, err
+	// Get account private key; an external signer keeps it to itself
+	if !w.UsesExternalSigner() {
+		privateKey, err := w.GetNodePrivateKeyBytes()
+		if err != nil {
+			return nil, err
+		}
+		response.AccountPrivateKey = hex.EncodeToString(privateKey)
 	}
-	response.AccountPrivateKey = hex.EncodeToString(privateKey)
 
 	// Return response
 	return &response, nil
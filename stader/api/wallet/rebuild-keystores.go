@@ -0,0 +1,40 @@
+package wallet
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+func rebuildKeystores(c *cli.Context, client string, force bool) (*api.RebuildKeystoresResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RebuildKeystoresResponse{
+		ActiveKeystore: validator.GetValidatorClientKeystore(cfg) == client,
+	}
+
+	// Rebuild the keystore
+	response.RebuiltKeys, response.UnchangedKeys, err = w.RebuildValidatorKeys(client, force)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -36,21 +36,6 @@ const (
 	findIterations uint = 100000
 )
 
-func rebuildValidatorKeys(c *cli.Context) (*api.RebuildWalletResponse, error) {
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get the keys for this bucket
-	err = w.RebuildLodestarValidatorKeys()
-	if err != nil {
-		return nil, err
-	}
-
-	return &api.RebuildWalletResponse{}, nil
-}
-
 func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse, error) {
 
 	// Get services
@@ -109,6 +94,7 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 
 		response.OperatorExists = operatorExists
 		if operatorExists {
+			// The keys aren't loaded into the running validator client, so they go through the doppelganger check when it's restarted
 			response.ValidatorKeys, err = walletutils.RecoverStaderKeys(pnr, nodeAccount.Address, w, false)
 			if err != nil {
 				return nil, err
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func setNextValidatorIndex(c *cli.Context, index uint64) (*api.SetNextValidatorIndexResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetNextValidatorIndexResponse{}
+
+	// Move the index
+	previousIndex, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	response.PreviousIndex = uint64(previousIndex)
+	if err := w.SetNextValidatorKeyIndex(uint(index)); err != nil {
+		return nil, err
+	}
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func signTransaction(c *cli.Context, tx *types.Transaction, from common.Address) (*api.SignTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignTransactionResponse{}
+
+	// Sign the transaction
+	signedTx, err := w.SignNodeTransaction(tx, from)
+	if err != nil {
+		return nil, err
+	}
+	response.SignedTx, err = signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
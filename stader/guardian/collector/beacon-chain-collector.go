@@ -158,8 +158,8 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 			return fmt.Errorf("Error getting proposer duties: %w", err)
 		}
 
-		for _, duty := range duties {
-			upcomingProposals += float64(duty)
+		for _, slots := range duties {
+			upcomingProposals += float64(len(slots))
 		}
 
 		return nil
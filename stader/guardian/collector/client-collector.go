@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stader-labs/stader-node/shared/services"
+)
+
+// Represents the collector for the Execution and Beacon client request counters and heads
+type ClientCollector struct {
+	// The number of requests sent to each client
+	requests *prometheus.Desc
+	// The number of requests retried after a transient error
+	retries *prometheus.Desc
+	// The number of requests that failed after all retries
+	failures *prometheus.Desc
+	// The number of requests delayed by the rate limit
+	throttled *prometheus.Desc
+	// The number of times the circuit breaker opened
+	circuitOpens *prometheus.Desc
+	// The number of requests moved from a client to the fallback
+	failovers *prometheus.Desc
+	// The latest block or slot of each client
+	headNumber *prometheus.Desc
+	// How long ago each client's head was produced
+	headAge *prometheus.Desc
+	// The latest finalized epoch of each Beacon client
+	finalizedEpoch *prometheus.Desc
+	// Whether each client's head stopped moving
+	stalled *prometheus.Desc
+	// Whether each Beacon client stopped finalizing
+	finalityStalled *prometheus.Desc
+	// The Execution client manager
+	ec *services.ExecutionClientManager
+	// The Beacon client manager
+	bc *services.BeaconClientManager
+}
+
+// Create a new ClientCollector instance
+func NewClientCollector(bc *services.BeaconClientManager, ec *services.ExecutionClientManager) *ClientCollector {
+	subsystem := "client"
+	labels := []string{"client", "endpoint"}
+	return &ClientCollector{
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The number of requests sent to the client",
+			labels, nil,
+		),
+		retries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "retries_total"),
+			"The number of requests retried after a transient error",
+			labels, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of requests that still failed after all retries",
+			labels, nil,
+		),
+		throttled: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "throttled_total"),
+			"The number of requests delayed by the rate limit",
+			labels, nil,
+		),
+		circuitOpens: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "circuit_opens_total"),
+			"The number of times the client was skipped after too many consecutive failures",
+			labels, nil,
+		),
+		failovers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failovers_total"),
+			"The number of requests moved from the client to the fallback",
+			labels, nil,
+		),
+		headNumber: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "head_number"),
+			"The latest block (Execution) or slot (Beacon) of the client",
+			labels, nil,
+		),
+		headAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "head_age_seconds"),
+			"How long ago the client's latest block or slot was produced",
+			labels, nil,
+		),
+		finalizedEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "finalized_epoch"),
+			"The latest finalized epoch of the Beacon client",
+			labels, nil,
+		),
+		stalled: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "stalled"),
+			"1 if the client claims to be synced but its head stopped moving",
+			labels, nil,
+		),
+		finalityStalled: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "finality_stalled"),
+			"1 if the Beacon client has not finalized an epoch for too long",
+			labels, nil,
+		),
+		ec: ec,
+		bc: bc,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ClientCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requests
+	channel <- collector.retries
+	channel <- collector.failures
+	channel <- collector.throttled
+	channel <- collector.circuitOpens
+	channel <- collector.failovers
+	channel <- collector.headNumber
+	channel <- collector.headAge
+	channel <- collector.finalizedEpoch
+	channel <- collector.stalled
+	channel <- collector.finalityStalled
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ClientCollector) Collect(channel chan<- prometheus.Metric) {
+	for _, stats := range collector.ec.GetEndpointStats() {
+		collector.collectStats(channel, "execution", stats)
+	}
+	for _, stats := range collector.bc.GetEndpointStats() {
+		collector.collectStats(channel, "beacon", stats)
+	}
+	for _, head := range collector.ec.GetEndpointHeads() {
+		collector.collectHead(channel, "execution", head)
+	}
+	for _, head := range collector.bc.GetEndpointHeads() {
+		collector.collectHead(channel, "beacon", head)
+		channel <- prometheus.MustNewConstMetric(collector.finalizedEpoch, prometheus.GaugeValue, float64(head.FinalizedEpoch), "beacon", head.Endpoint)
+		channel <- prometheus.MustNewConstMetric(collector.finalityStalled, prometheus.GaugeValue, boolToFloat(head.FinalityStalled), "beacon", head.Endpoint)
+	}
+}
+
+func (collector *ClientCollector) collectStats(channel chan<- prometheus.Metric, client string, stats services.ClientEndpointStats) {
+	endpoint := stats.Endpoint
+	channel <- prometheus.MustNewConstMetric(collector.requests, prometheus.CounterValue, float64(stats.Requests), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.retries, prometheus.CounterValue, float64(stats.Retries), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.failures, prometheus.CounterValue, float64(stats.Failures), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.throttled, prometheus.CounterValue, float64(stats.Throttled), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.circuitOpens, prometheus.CounterValue, float64(stats.CircuitOpens), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.failovers, prometheus.CounterValue, float64(stats.Failovers), client, endpoint)
+}
+
+func (collector *ClientCollector) collectHead(channel chan<- prometheus.Metric, client string, head services.ClientEndpointHead) {
+	// Skip clients that haven't reported a head yet
+	if head.HeadTime.IsZero() {
+		return
+	}
+	endpoint := head.Endpoint
+	channel <- prometheus.MustNewConstMetric(collector.headNumber, prometheus.GaugeValue, float64(head.HeadNumber), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.headAge, prometheus.GaugeValue, time.Since(head.HeadTime).Seconds(), client, endpoint)
+	channel <- prometheus.MustNewConstMetric(collector.stalled, prometheus.GaugeValue, boolToFloat(head.IsStalled), client, endpoint)
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
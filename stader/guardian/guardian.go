@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package guardian
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -94,6 +95,12 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Refresh the metrics as soon as a new epoch starts
+	epochTrigger := services.NewEpochTrigger(context.Background(), bc)
+
+	// Compare the Execution clients in the background if the consistency check is enabled
+	ec.StartConsistencyChecks(context.Background())
+
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
@@ -128,7 +135,7 @@ func run(c *cli.Context) error {
 				continue
 			}
 			metricsCache.UpdateMetricsContainer(networkStateCache)
-			time.Sleep(tasksInterval)
+			epochTrigger.Wait(tasksInterval)
 		}
 
 		wg.Done()
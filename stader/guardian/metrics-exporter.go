@@ -73,11 +73,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	beaconCollector := collector.NewBeaconCollector(bc, ec, nodeAccountAddr, stateLocker)
 	networkCollector := collector.NewNetworkCollector(bc, ec, nodeAccountAddr, stateLocker)
 	operatorCollector := collector.NewOperatorCollector(bc, ec, nodeAccountAddr, stateLocker)
+	clientCollector := collector.NewClientCollector(bc, ec)
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(networkCollector)
 	registry.MustRegister(operatorCollector)
+	registry.MustRegister(clientCollector)
 
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
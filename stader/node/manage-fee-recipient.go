@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package node
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/stader-labs/stader-node/shared/services"
 	"github.com/stader-labs/stader-node/shared/services/beacon"
 	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
 	staderService "github.com/stader-labs/stader-node/shared/services/stader"
 	"github.com/stader-labs/stader-node/shared/services/wallet"
 	"github.com/stader-labs/stader-node/shared/utils/eth1"
@@ -180,8 +182,11 @@ func (m *manageFeeRecipient) run() error {
 	} else if !correctAddress {
 		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	} else {
-		// Files are all correct, return.
+		// Files are all correct, but each validator's own fee recipient can still drift
 		m.log.Printlnf("Fee recipient files are all correct, no action required.")
+		if err := m.reconcileValidatorFeeRecipients(correctFeeRecipient); err != nil && !errors.Is(err, keymanager.ErrUnavailable) {
+			m.log.Printlnf("WARNING: Could not check the fee recipient of each validator: %s", err.Error())
+		}
 		return nil
 	}
 
@@ -199,6 +204,14 @@ func (m *manageFeeRecipient) run() error {
 		return nil
 	}
 
+	// Apply it to each validator without a restart if possible
+	err = m.reconcileValidatorFeeRecipients(correctFeeRecipient)
+	if err == nil {
+		m.log.Println("Fee recipient files updated successfully and applied to every validator through the keymanager API.")
+		return nil
+	}
+	m.log.Printlnf("Could not apply the fee recipient through the keymanager API (%s)", err.Error())
+
 	// Restart the VC
 	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
 	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
@@ -211,3 +224,36 @@ func (m *manageFeeRecipient) run() error {
 	return nil
 
 }
+
+// Make sure every validator the VC has loaded uses the correct fee recipient, fixing the ones that drifted
+func (m *manageFeeRecipient) reconcileValidatorFeeRecipients(correctFeeRecipient common.Address) error {
+	keymanagerClient := validator.GetKeymanagerClient(m.cfg)
+	if keymanagerClient == nil {
+		return keymanager.ErrUnavailable
+	}
+	return reconcileFeeRecipients(keymanagerClient, &m.log, correctFeeRecipient)
+}
+
+// Set the fee recipient of every loaded key that doesn't use the correct one.
+// This includes keys that aren't registered with the operator yet, since their default is the stale recipient from the old fee recipient file too.
+func reconcileFeeRecipients(keymanagerClient *keymanager.Client, log *log.ColorLogger, correctFeeRecipient common.Address) error {
+	pubkeys, err := keymanagerClient.ListPubkeys()
+	if err != nil {
+		return err
+	}
+
+	for _, pubkey := range pubkeys {
+		feeRecipient, err := keymanagerClient.GetFeeRecipient(pubkey)
+		if err != nil {
+			return err
+		}
+		if feeRecipient == correctFeeRecipient {
+			continue
+		}
+		log.Printlnf("Validator %s has fee recipient %s instead of %s, updating it...", pubkey.Hex(), feeRecipient.Hex(), correctFeeRecipient.Hex())
+		if err := keymanagerClient.SetFeeRecipient(pubkey, correctFeeRecipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// A keymanager API that keeps a fee recipient for each loaded key and counts the updates
+type mockFeeRecipientKeymanager struct {
+	feeRecipients map[string]common.Address
+	failUpdates   bool
+	updates       int
+}
+
+func newMockFeeRecipientKeymanager(t *testing.T, m *mockFeeRecipientKeymanager) *keymanager.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v1/keystores" {
+			keystores := []keymanager.Keystore{}
+			for pubkey := range m.feeRecipients {
+				keystores = append(keystores, keymanager.Keystore{ValidatingPubkey: pubkey})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": keystores})
+			return
+		}
+		pubkey := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/"), "/feerecipient")
+		feeRecipient, loaded := m.feeRecipients[pubkey]
+		if !loaded || !strings.HasSuffix(r.URL.Path, "/feerecipient") {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"pubkey": pubkey, "ethaddress": feeRecipient}})
+		case http.MethodPost:
+			if m.failUpdates {
+				http.Error(w, `{"message":"read-only"}`, http.StatusInternalServerError)
+				return
+			}
+			var request struct {
+				Ethaddress common.Address `json:"ethaddress"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+			m.feeRecipients[pubkey] = request.Ethaddress
+			m.updates++
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return keymanager.NewClient(server.URL, "")
+}
+
+func TestReconcileFeeRecipients(t *testing.T) {
+	correct := common.HexToAddress("0x01")
+	stale := common.HexToAddress("0x02")
+	pubkey := func(b byte) string {
+		var pubkey stadertypes.ValidatorPubkey
+		pubkey[0] = b
+		return "0x" + pubkey.Hex()
+	}
+
+	tests := []struct {
+		name            string
+		feeRecipients   map[string]common.Address
+		failUpdates     bool
+		expectedError   bool
+		expectedUpdates int
+	}{
+		{"no keys loaded", map[string]common.Address{}, false, false, 0},
+		{"all correct", map[string]common.Address{pubkey(1): correct, pubkey(2): correct}, false, false, 0},
+		// Keys that aren't registered yet are loaded with the stale default just like registered ones
+		{"drifted keys are updated", map[string]common.Address{pubkey(1): correct, pubkey(2): stale, pubkey(3): stale}, false, false, 2},
+		{"failed update", map[string]common.Address{pubkey(1): stale}, true, true, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockFeeRecipientKeymanager{feeRecipients: test.feeRecipients, failUpdates: test.failUpdates}
+			logger := log.NewColorLogger(ManageFeeRecipientColor)
+			err := reconcileFeeRecipients(newMockFeeRecipientKeymanager(t, m), &logger, correct)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("got %v, expected an error: %t", err, test.expectedError)
+			}
+			if m.updates != test.expectedUpdates {
+				t.Fatalf("got %d updates, expected %d", m.updates, test.expectedUpdates)
+			}
+			if test.expectedError {
+				return
+			}
+			for key, feeRecipient := range m.feeRecipients {
+				if feeRecipient != correct {
+					t.Fatalf("validator %s still has fee recipient %s", key, feeRecipient.Hex())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	staderUtils "github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// Manage validator settings task
+type manageValidatorSettings struct {
+	c   *cli.Context
+	log log.ColorLogger
+	w   *wallet.Wallet
+	pnr *stader.PermissionlessNodeRegistryContractManager
+	ec  *services.ExecutionClientManager
+	bc  beacon.Client
+
+	// The settings seen on the last run, to tell when one was cleared
+	settingsLoaded   bool
+	graffitiTemplate string
+	gasLimit         uint64
+}
+
+// Create manage validator settings task
+func newManageValidatorSettings(c *cli.Context, logger log.ColorLogger) (*manageValidatorSettings, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageValidatorSettings{
+		c:   c,
+		log: logger,
+		w:   w,
+		pnr: pnr,
+		ec:  ec,
+		bc:  bc,
+	}, nil
+
+}
+
+// Apply the configured graffiti and gas limit to the validators loaded in the validator client
+func (m *manageValidatorSettings) run() error {
+
+	// Reload the settings so changes made with the CLI are picked up without restarting the daemon
+	cfg, err := staderUtils.LoadConfigFromFile(os.ExpandEnv(m.c.GlobalString("settings")))
+	if err != nil {
+		return fmt.Errorf("error loading the settings: %w", err)
+	}
+	if cfg == nil {
+		return nil
+	}
+	graffitiTemplate := cfg.StaderNode.GraffitiTemplate.Value.(string)
+	gasLimit := cfg.StaderNode.ValidatorGasLimit.Value.(uint64)
+	keymanagerEnabled := cfg.StaderNode.KeymanagerApi.Value.(bool)
+
+	// Overrides only need removing once, when a setting is cleared; the CLI removes them itself if the daemon wasn't running
+	clearGraffiti := m.settingsLoaded && m.graffitiTemplate != "" && graffitiTemplate == ""
+	clearGasLimit := m.settingsLoaded && m.gasLimit != 0 && gasLimit == 0
+	if keymanagerEnabled && graffitiTemplate == "" && gasLimit == 0 && !clearGraffiti && !clearGasLimit {
+		m.settingsLoaded, m.graffitiTemplate, m.gasLimit = true, graffitiTemplate, gasLimit
+		return nil
+	}
+
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	operatorId, err := node.GetOperatorId(m.pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error GetOperatorId: %w", err)
+	}
+	if operatorId.Int64() == 0 {
+		return nil
+	}
+	ecVersion, err := m.ec.Version()
+	if err != nil {
+		return fmt.Errorf("error getting execution client version: %w", err)
+	}
+
+	// Without the keymanager API, the validator client takes the graffiti from a file when it starts
+	if !keymanagerEnabled {
+		updated, err := validator.UpdateGraffitiFile(cfg, m.bc, m.pnr, operatorId, ecVersion)
+		if err != nil {
+			return fmt.Errorf("error updating the graffiti file: %w", err)
+		}
+		if updated {
+			m.log.Println("Updated the graffiti file; the validator client uses it after it restarts.")
+		}
+		return nil
+	}
+
+	err = validator.ClearValidatorSettings(cfg, m.pnr, operatorId, nodeAccount.Address, clearGraffiti, clearGasLimit)
+	if errors.Is(err, keymanager.ErrUnavailable) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error clearing validator settings: %w", err)
+	}
+	if clearGraffiti {
+		m.log.Println("Removed the graffiti of each validator, the validator client's default applies again")
+	}
+	if clearGasLimit {
+		m.log.Println("Removed the gas limit of each validator, the validator client's default applies again")
+	}
+	m.settingsLoaded, m.graffitiTemplate, m.gasLimit = true, graffitiTemplate, gasLimit
+
+	settings, err := validator.ReconcileValidatorSettings(cfg, m.bc, m.pnr, operatorId, nodeAccount.Address, ecVersion, true)
+	if errors.Is(err, keymanager.ErrUnavailable) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error applying validator settings: %w", err)
+	}
+	for _, validatorSettings := range settings {
+		if validatorSettings.Updated {
+			m.log.Printlnf("Updated the graffiti and gas limit of validator %s", validatorSettings.Pubkey.Hex())
+		}
+	}
+	return nil
+
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package node
 
 import (
+	"context"
 	"crypto/ecdsa"
 	_ "embed"
 	"encoding/hex"
@@ -35,6 +36,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	eCryto "github.com/ethereum/go-ethereum/crypto"
 
 	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
@@ -46,14 +48,17 @@ import (
 	"github.com/stader-labs/stader-node/shared/utils/validator"
 	"github.com/stader-labs/stader-node/stader-lib/node"
 	stader_lib "github.com/stader-labs/stader-node/stader-lib/stader"
+	"github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
 	"github.com/stader-labs/stader-node/shared/services/config"
 	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore/web3signer"
 	"github.com/stader-labs/stader-node/shared/utils/log"
 )
 
@@ -69,6 +74,7 @@ const (
 	MaxConcurrentEth1Requests   = 200
 	ManageFeeRecipientColor     = color.FgHiCyan
 	MerkleProofsDownloaderColor = color.FgHiBlue
+	ValidatorSettingsColor      = color.FgHiMagenta
 	ErrorColor                  = color.FgRed
 	InfoColor                   = color.FgHiGreen
 	blocksPerThreeEpoch         = 96
@@ -139,6 +145,10 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	manageValidatorSettings, err := newManageValidatorSettings(c, log.NewColorLogger(ValidatorSettingsColor))
+	if err != nil {
+		return err
+	}
 
 	// Initialize loggers
 	errorLog := log.NewColorLogger(ErrorColor)
@@ -149,6 +159,12 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Re-check the fee recipient as soon as a new checkpoint is finalized
+	finalityTrigger := services.NewFinalityTrigger(context.Background(), bc)
+
+	// Compare the Execution clients in the background if the consistency check is enabled
+	ec.StartConsistencyChecks(context.Background())
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(4)
@@ -188,6 +204,12 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// Presigned exits are signed by Web3Signer when it holds the validator keys
+			var remoteSigner *web3signer.Keystore
+			if web3SignerUrl := cfg.StaderNode.Web3SignerUrl.Value.(string); web3SignerUrl != "" {
+				remoteSigner = web3signer.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), web3SignerUrl)
+			}
+
 			// make a map of all validators actually registered with stader
 			// user might just move the validator keys to the directory. we don't wanna send the presigned msg of them
 			infoLog.Println("Building a map of user validators registered with stader")
@@ -238,11 +260,14 @@ func run(c *cli.Context) error {
 
 				for _, validatorPubKey := range validatorKeyBatch {
 					infoLog.Printf("Checking validator pubkey %s\n", validatorPubKey.String())
-					validatorKeyPair, err := w.GetValidatorKeyByPubkey(validatorPubKey)
-					// log the errors and continue. dont need to sleep post an error
-					if err != nil {
-						errorLog.Printf("Could not find validator private key for %s with err: %s\n", validatorPubKey, err.Error())
-						continue
+					var validatorKeyPair *eth2types.BLSPrivateKey
+					if remoteSigner == nil {
+						validatorKeyPair, err = w.GetValidatorKeyByPubkey(validatorPubKey)
+						// log the errors and continue. dont need to sleep post an error
+						if err != nil {
+							errorLog.Printf("Could not find validator private key for %s with err: %s\n", validatorPubKey, err.Error())
+							continue
+						}
 					}
 
 					validatorInfo, ok := registeredValidators[validatorPubKey]
@@ -293,7 +318,12 @@ func run(c *cli.Context) error {
 					}
 
 					// get the presigned msg
-					exitSignature, _, err := validator.GetSignedExitMessage(validatorKeyPair, validatorStatus.Index, exitEpoch, signatureDomain)
+					var exitSignature types.ValidatorSignature
+					if remoteSigner != nil {
+						exitSignature, err = signRemoteExitMessage(bc, remoteSigner, network, validatorPubKey, validatorStatus.Index, exitEpoch, signatureDomain)
+					} else {
+						exitSignature, _, err = validator.GetSignedExitMessage(validatorKeyPair, validatorStatus.Index, exitEpoch, signatureDomain)
+					}
 					if err != nil {
 						errorLog.Printf("Failed to generate the SignedExitMessage for validator with beacon chain index: %d with err: %s\n", validatorStatus.Index, err.Error())
 						continue
@@ -367,10 +397,14 @@ func run(c *cli.Context) error {
 					if err := manageFeeRecipient.run(); err != nil {
 						errorLog.Println(err)
 					}
+					// Keep the graffiti and gas limit of each validator in line with the settings
+					if err := manageValidatorSettings.run(); err != nil {
+						errorLog.Println(err)
+					}
 					time.Sleep(taskCooldown)
 				}
 			}
-			time.Sleep(feeRecepientPollingInterval)
+			finalityTrigger.Wait(feeRecepientPollingInterval)
 		}
 		wg.Done()
 	}()
@@ -425,14 +459,6 @@ func run(c *cli.Context) error {
 				continue
 			}
 
-			privateKey, err := w.GetNodePrivateKey()
-			if err != nil {
-				errorLog.Printlnf("Error GetNodePrivateKey %+v", err)
-				time.Sleep(nodeDiversityTrackerCooldown)
-
-				continue
-			}
-
 			cfg, err := services.GetConfig(c)
 			if err != nil {
 				errorLog.Printlnf("Error getconfig %+v", err)
@@ -451,7 +477,7 @@ func run(c *cli.Context) error {
 				continue
 			}
 
-			request, err := makeNodeDiversityRequest(message, privateKey)
+			request, err := makeSignedNodeDiversityRequest(message, walletNodeDiversitySigner(w))
 			if err != nil {
 				errorLog.Printlnf("Error makesNodeDiversityRequest %+v", err)
 				time.Sleep(nodeDiversityTrackerCooldown)
@@ -566,14 +592,32 @@ func makeNodeDiversityMessage(
 }
 
 func makeNodeDiversityRequest(msg *stader_backend.NodeDiversity, privateKey *ecdsa.PrivateKey) (*stader_backend.NodeDiversityRequest, error) {
+	return makeSignedNodeDiversityRequest(msg, func(msgBytes []byte) ([]byte, error) {
+		return eCryto.Sign(accounts.TextHash(msgBytes), privateKey)
+	})
+}
+
+// Sign node diversity messages through the wallet, so an external signer can hold the node key
+func walletNodeDiversitySigner(w *wallet.Wallet) func([]byte) ([]byte, error) {
+	return func(msgBytes []byte) ([]byte, error) {
+		signature, err := w.SignMessage(string(msgBytes))
+		if err != nil {
+			return nil, err
+		}
+		// The wallet returns a V of 27 or 28, but node diversity messages use the raw recovery ID like makeNodeDiversityRequest
+		signature[eCryto.RecoveryIDOffset] -= 27
+		return signature, nil
+	}
+}
+
+// Make a node diversity request, signing the message as a personal message with the given function
+func makeSignedNodeDiversityRequest(msg *stader_backend.NodeDiversity, sign func([]byte) ([]byte, error)) (*stader_backend.NodeDiversityRequest, error) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
 		return nil, err
 	}
 
-	msgHashed := accounts.TextHash(msgBytes)
-
-	signedMessage, err := eCryto.Sign(msgHashed, privateKey)
+	signedMessage, err := sign(msgBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -634,3 +678,27 @@ func deployDefaultFeeRecipientFile(c *cli.Context) error {
 	return nil
 
 }
+
+// Sign a voluntary exit message with a validator key held by Web3Signer
+func signRemoteExitMessage(bc beacon.Client, remoteSigner *web3signer.Keystore, network cfgtypes.Network, pubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
+	signingRoot, err := validator.GetExitMessageSigningRoot(validatorIndex, epoch, signatureDomain)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Exits are signed with the same fork version at every fork, matching the signature domain
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+	forkVersion, err := hexutil.Decode(eth2.GetExitForkVersion(network))
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	return remoteSigner.SignVoluntaryExit(pubkey, web3signer.ForkInfo{
+		PreviousVersion:       forkVersion,
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: eth2Config.GenesisValidatorsRoot,
+	}, epoch, validatorIndex, signingRoot)
+}
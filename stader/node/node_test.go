@@ -6,10 +6,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 )
 
@@ -99,6 +102,62 @@ func TestVerifySignatureFailed(t *testing.T) {
 	}
 }
 
+func TestVerifyWalletSignature(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := wallet.NewWallet(filepath.Join(dir, "wallet"), 1337, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(wallet.DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodePublicKey, err := w.GetNodePubkey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The signature keeps the raw recovery ID, so it recovers the node account without adjusting V
+	sign := walletNodeDiversitySigner(w)
+	signature, err := sign([]byte("node diversity"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := signature[crypto.RecoveryIDOffset]; v > 1 {
+		t.Fatalf("got V %d, expected 0 or 1", v)
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte("node diversity")), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*pubkey) != nodeAccount.Address {
+		t.Fatalf("signature recovered %s instead of the node account %s", crypto.PubkeyToAddress(*pubkey).Hex(), nodeAccount.Address.Hex())
+	}
+
+	req, err := makeSignedNodeDiversityRequest(&stader_backend.NodeDiversity{
+		ExecutionClient:      ExecutionClient,
+		ConsensusClient:      ConsensusClient,
+		ValidatorClient:      ValidatorClient,
+		TotalNonTerminalKeys: 10,
+		NodeAddress:          nodeAccount.Address.String(),
+		NodePublicKey:        nodePublicKey,
+		Relays:               "ultrasound,aestus",
+	}, sign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifySignature(t, req.Message, req.Signature) {
+		t.Error("verified should success")
+	}
+}
+
 func verifySignature(t *testing.T, msg *stader_backend.NodeDiversity, signEncoded string) bool {
 	t.Helper()
 
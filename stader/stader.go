@@ -781,6 +781,10 @@ Public License instead of this License. But first, please read
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "offline-export",
+			Usage: "Export node account transactions unsigned for offline signing instead of signing and sending them",
+		},
 		cli.StringFlag{
 			Name:  "metricsAddress, m",
 			Usage: "Address to serve metrics on if enabled",